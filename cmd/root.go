@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/s42yt/node-spark/internal"
@@ -67,10 +68,56 @@ func init() {
 	rootCmd.PersistentFlags().Bool("wipe", false, "Wipe all node-spark data (hidden)")
 	rootCmd.PersistentFlags().MarkHidden("wipe")
 
+	// Skip SHA-256 checksum verification of downloaded archives
+	installCmd.Flags().Bool("no-verify", false, "Skip verification of the downloaded archive")
+	installCmd.Flags().String("verify", "", "Verification policy for the downloaded archive: checksum-only (default), strict (also checks the OpenPGP signature; not available until a release-team keyring is bundled), or off")
+	installCmd.Flags().Int("jobs", 0, "Number of parallel workers for archive extraction (default: number of CPUs)")
+	installCmd.Flags().Int("download-chunks", 0, "Number of concurrent range requests for downloading the archive (default: min(8, GOMAXPROCS))")
+	installCmd.Flags().String("dist", "", "Distribution channel to install from: release, nightly, rc, or unofficial (default: release)")
+	installCmd.Flags().String("arch", "", "Architecture to install (x64, x86, arm64, armv7l), instead of the one node-spark itself runs as; multiple architectures of the same version coexist side-by-side")
+	installCmd.Flags().Int("concurrency", 0, "Number of Node.js versions to download/extract at once when given more than one version (default: number of CPUs)")
+	installCmd.Flags().String("runtime", "node", "Runtime to install: node, iojs, deno, or bun")
+	currentCmd.Flags().String("runtime", "node", "Runtime to query: node, iojs, deno, or bun")
+	listCmd.Flags().String("runtime", "node", "Runtime to list: node, iojs, deno, or bun")
+
+	// Auto-install the resolved version when it isn't installed yet
+	useCmd.Flags().Bool("install-missing", false, "Install the resolved version if it isn't installed yet")
+	autoCmd.Flags().Bool("install-missing", false, "Install the resolved version if it isn't installed yet")
+	resolveCmd.Flags().Bool("install-missing", false, "Install the resolved version if it isn't installed yet")
+	resolveCmd.Flags().Bool("silent", false, "Suppress progress output; used by the chpwd/PROMPT_COMMAND shell hook")
+	envCmd.Flags().Bool("install-missing", false, "Install the resolved version if it isn't installed yet")
+
+	// 'use' only updates node-spark's own bookkeeping unless --global asks
+	// for the old persistent symlink/junction/PATH-registry switch.
+	useCmd.Flags().Bool("global", false, "Switch the version persistently system-wide instead of just marking it active for 'nsk env'")
+
+	// Per-shell activation/deactivation scripts
+	envCmd.Flags().String("shell", "", "Shell to generate the activation script for (bash, zsh, fish, powershell, cmd)")
+	envCmd.MarkFlagRequired("shell")
+	deactivateCmd.Flags().String("shell", "", "Shell to generate the deactivation script for (bash, zsh, fish, powershell, cmd)")
+	deactivateCmd.MarkFlagRequired("shell")
+
+	// Self-update
+	updateCmd.Flags().String("channel", "stable", "Release channel to update from: stable or beta")
+	updateCmd.Flags().Bool("rollback", false, "Restore the binary replaced by the last 'nsk update' instead of checking for a new one")
+
+	// list-remote filters
+	listRemoteCmd.Flags().String("lts", "", "Only show LTS versions; optionally filter by codename (e.g. hydrogen)")
+	listRemoteCmd.Flags().Lookup("lts").NoOptDefVal = "*"
+	listRemoteCmd.Flags().Int("major", 0, "Only show versions with this major version number")
+	listRemoteCmd.Flags().String("range", "", `Only show versions satisfying this range (e.g. ">=18 <21")`)
+	listRemoteCmd.Flags().Int("limit", 0, "Limit the number of versions shown")
+
 	// Add subcommands
 	rootCmd.AddCommand(installCmd)
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(updateCmd)
 	rootCmd.AddCommand(useCmd)
+	rootCmd.AddCommand(envCmd)
+	rootCmd.AddCommand(deactivateCmd)
+	rootCmd.AddCommand(autoCmd)
+	rootCmd.AddCommand(resolveCmd)
+	rootCmd.AddCommand(shellInitCmd)
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(listRemoteCmd)
 	rootCmd.AddCommand(currentCmd)
@@ -80,17 +127,73 @@ func init() {
 	// Add global installation commands
 	rootCmd.AddCommand(installGlobalCmd)
 	rootCmd.AddCommand(uninstallGlobalCmd)
+
+	// Add settings commands
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configListCmd)
+	rootCmd.AddCommand(configCmd)
+
+	// Add package manager commands
+	rootCmd.AddCommand(npmCmd)
+	rootCmd.AddCommand(pnpmCmd)
+	rootCmd.AddCommand(yarnCmd)
+
+	// Add alias commands
+	aliasCmd.AddCommand(aliasSetCmd)
+	aliasCmd.AddCommand(aliasLsCmd)
+	aliasCmd.AddCommand(aliasRmCmd)
+	rootCmd.AddCommand(aliasCmd)
 }
 
 // --- Subcommands ---
 
 var installCmd = &cobra.Command{
-	Use:   "install [version]",
-	Short: "Install a specific Node.js version",
-	Args:  cobra.ExactArgs(1),
+	Use:   "install <version> [version...]",
+	Short: "Install one or more Node.js versions",
+	Long: `Install a Node.js version. Given more than one version (e.g. 'nsk install 18
+20 22'), they are downloaded and extracted concurrently through a bounded
+worker pool (see internal/install_batch.go's InstallVersions), bounded by
+--concurrency (default: number of CPUs). --runtime, --dist, and --arch only
+apply to a single-version install.`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		noVerify, _ := cmd.Flags().GetBool("no-verify")
+		if verify, _ := cmd.Flags().GetString("verify"); verify != "" {
+			cfg.VerificationPolicy = pkg.VerificationPolicy(verify)
+		}
+		if jobs, _ := cmd.Flags().GetInt("jobs"); jobs > 0 {
+			internal.SetExtractionJobs(jobs)
+		}
+		if chunks, _ := cmd.Flags().GetInt("download-chunks"); chunks > 0 {
+			internal.SetDownloadChunks(chunks)
+		}
+
+		if len(args) > 1 {
+			concurrency, _ := cmd.Flags().GetInt("concurrency")
+			if err := internal.InstallVersions(args, cfg, concurrency); err != nil {
+				return err
+			}
+			return pkg.SaveConfig(cfgPath, cfg)
+		}
+
 		version := args[0]
-		err := internal.InstallVersion(version, cfg)
+
+		runtimeName, _ := cmd.Flags().GetString("runtime")
+		if runtimeName != "" && runtimeName != "node" {
+			if err := internal.InstallRuntime(runtimeName, version, cfg); err != nil {
+				return err
+			}
+			return pkg.SaveConfig(cfgPath, cfg)
+		}
+
+		if dist, _ := cmd.Flags().GetString("dist"); dist != "" {
+			if err := internal.SetDistribution(dist); err != nil {
+				return err
+			}
+		}
+		archOverride, _ := cmd.Flags().GetString("arch")
+		_, err := internal.InstallNodeVersion(version, cfg, noVerify, archOverride, true)
 		if err != nil {
 			return err
 		}
@@ -100,25 +203,253 @@ var installCmd = &cobra.Command{
 }
 
 var useCmd = &cobra.Command{
-	Use:   "use [version]",
-	Short: "Switch to use a specific Node.js version",
-	Args:  cobra.ExactArgs(1),
+	Use:   "use [version|runtime@version] [arch]",
+	Short: "Switch to use a specific Node.js version, or another runtime with 'runtime@version' (e.g. deno@1.46)",
+	Long: `Switch to use a specific Node.js version. With no argument, resolves the
+version from .nvmrc/.node-version/package.json in the current directory,
+same as 'nsk auto'. A 'runtime@version' argument (e.g. deno@1.46.3,
+bun@1.1.0) switches that runtime instead.
+
+An optional second argument pins which installed architecture (x64, x86,
+arm64, armv7l) to activate, for versions installed side-by-side under more
+than one. With no arch argument, node-spark prefers the architecture it is
+itself running as and falls back to another installed one if that build
+can't run here (e.g. Rosetta, Windows on ARM).
+
+By default this only updates which version node-spark considers active; it
+does not touch PATH. Run 'nsk env --shell <bash|zsh|fish|powershell|cmd>' to
+put it on PATH for the current shell session. Pass --global to instead
+switch it persistently everywhere, the way 'nsk use' worked before 'env' and
+'deactivate' existed.`,
+	Args: cobra.MaximumNArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		version := args[0]
-		err := internal.SetActiveVersion(version, cfg)
+		if len(args) >= 1 {
+			if runtimeName, version, ok := strings.Cut(args[0], "@"); ok {
+				if err := internal.UseRuntime(runtimeName, version, cfg); err != nil {
+					return err
+				}
+				return pkg.SaveConfig(cfgPath, cfg)
+			}
+		}
+
+		installMissing, _ := cmd.Flags().GetBool("install-missing")
+		global, _ := cmd.Flags().GetBool("global")
+
+		var arch string
+		versionArgs := args
+		if len(args) == 2 {
+			versionArgs = args[:1]
+			arch = args[1]
+		}
+
+		version, err := resolveUseTarget(versionArgs, installMissing)
 		if err != nil {
 			return err
 		}
+
+		if err := internal.SetActiveVersion(version, arch, cfg, global); err != nil {
+			return err
+		}
+		if !global {
+			fmt.Printf("Node.js %s is now the active node-spark version.\n", version)
+			fmt.Println(`Run 'eval "$(nsk env --shell bash)"' (or zsh/fish/powershell/cmd) to put it on PATH here, or pass --global to switch it everywhere.`)
+		}
 		// Save config after successful use command
 		return pkg.SaveConfig(cfgPath, cfg)
 	},
 }
 
+var envCmd = &cobra.Command{
+	Use:   "env [version]",
+	Short: "Print a shell script that activates a Node.js version for the current shell session only",
+	Long: `Print a shell script that prepends the given Node.js version's bin
+directory to PATH for the current shell session, without touching any other
+terminal or persisting past it. With no version argument, resolves from
+.nvmrc/.node-version/package.json in the current directory, same as 'nsk
+use'/'nsk auto'.
+
+Eval its output in your shell:
+
+  eval "$(nsk env 20.11.0 --shell bash)"
+  eval "$(nsk env --shell zsh)"
+  nsk env --shell fish | source
+  nsk env --shell powershell | Invoke-Expression
+  nsk env --shell cmd > activate.bat && activate.bat
+
+Pair with 'nsk deactivate --shell <shell>' to restore the previous PATH.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		shell, _ := cmd.Flags().GetString("shell")
+		installMissing, _ := cmd.Flags().GetBool("install-missing")
+
+		version, err := resolveUseTarget(args, installMissing)
+		if err != nil {
+			return err
+		}
+
+		if err := internal.UseVersion(version, "", cfg, false); err != nil {
+			return err
+		}
+		if err := pkg.SaveConfig(cfgPath, cfg); err != nil {
+			return err
+		}
+
+		script, err := internal.GenerateActivationScript(shell, version, cfg)
+		if err != nil {
+			return err
+		}
+		fmt.Print(script)
+		return nil
+	},
+}
+
+var deactivateCmd = &cobra.Command{
+	Use:   "deactivate",
+	Short: "Print a shell script that undoes 'nsk env', restoring the previous PATH",
+	Long: `Print a shell script that restores PATH from NODE_SPARK_PREV_PATH and clears
+the node-spark session env vars 'nsk env' set. Eval its output the same way
+you evaled 'nsk env':
+
+  eval "$(nsk deactivate --shell bash)"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		shell, _ := cmd.Flags().GetString("shell")
+
+		script, err := internal.GenerateDeactivationScript(shell)
+		if err != nil {
+			return err
+		}
+		fmt.Print(script)
+		return nil
+	},
+}
+
+// resolveUseTarget resolves the version argument when given (through aliases,
+// "lts"/"lts/<codename>", and semver ranges via ResolveVersionSpec), or
+// consults the project resolver (.nvmrc/.node-version/engines.node) when args
+// is empty.
+func resolveUseTarget(args []string, installMissing bool) (string, error) {
+	if len(args) == 1 {
+		return internal.ResolveVersionSpec(args[0], cfg, installMissing)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	spec, source, err := internal.FindProjectVersionSpec(cwd)
+	if err != nil {
+		return "", err
+	}
+	if spec == "" {
+		return "", fmt.Errorf("no version argument given and no .nvmrc/.node-version/package.json engines.node found")
+	}
+
+	version, err := internal.ResolveVersionSpec(spec, cfg, installMissing)
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Printf("Resolved %s (from %s) to Node.js %s\n", spec, source, version)
+	return version, nil
+}
+
+var autoCmd = &cobra.Command{
+	Use:   "auto",
+	Short: "Activate the Node.js version matching .nvmrc/.node-version/package.json in the current directory",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		installMissing, _ := cmd.Flags().GetBool("install-missing")
+
+		version, err := resolveUseTarget(nil, installMissing)
+		if err != nil {
+			return err
+		}
+
+		// "auto" always activates globally - it exists to drive the shared
+		// "current" symlink/junction that every terminal's PATH points at.
+		if err := internal.SetActiveVersion(version, "", cfg, true); err != nil {
+			return err
+		}
+		return pkg.SaveConfig(cfgPath, cfg)
+	},
+}
+
+var resolveCmd = &cobra.Command{
+	Use:   "resolve",
+	Short: "Detect and activate the Node.js version for the current directory (used by the shell hook)",
+	Long: `Resolve and activate the Node.js version matching .nvmrc/.node-version/package.json
+engines.node in the current directory, via the pluggable detector chain in
+internal/autoswitch.go. This is what the hook printed by 'nsk hook'/'nsk
+shell-init' calls on every prompt with --silent; 'nsk auto' runs the same
+resolution interactively.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		installMissing, _ := cmd.Flags().GetBool("install-missing")
+		silent, _ := cmd.Flags().GetBool("silent")
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+
+		if err := internal.ResolveAndActivate(cwd, cfg, installMissing, silent); err != nil {
+			return err
+		}
+		return pkg.SaveConfig(cfgPath, cfg)
+	},
+}
+
+var shellInitCmd = &cobra.Command{
+	Use:     "shell-init [bash|zsh|fish|powershell]",
+	Aliases: []string{"hook"},
+	Short:   "Print a shell hook that runs 'nsk resolve --silent' whenever the working directory changes",
+	Long: `Print a shell hook that runs 'nsk resolve --silent' whenever the working
+directory changes.
+
+Add one of the following to your shell's startup file:
+
+  eval "$(nsk shell-init bash)"        # ~/.bashrc
+  eval "$(nsk shell-init zsh)"         # ~/.zshrc
+  nsk shell-init fish | source         # ~/.config/fish/config.fish
+  nsk shell-init powershell | Invoke-Expression   # $PROFILE
+
+'nsk hook' is an alias for the same command.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		hook, err := internal.GenerateShellHook(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Print(hook)
+		return nil
+	},
+}
+
 var listCmd = &cobra.Command{
 	Use:     "list",
 	Short:   "List installed Node.js versions",
 	Aliases: []string{"ls"},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		runtimeName, _ := cmd.Flags().GetString("runtime")
+		if runtimeName != "" && runtimeName != "node" {
+			if _, err := internal.GetRuntime(runtimeName); err != nil {
+				return err
+			}
+			state := cfg.Runtimes[runtimeName]
+			if len(state.InstalledVersions) == 0 {
+				fmt.Printf("No %s versions installed yet.\n", runtimeName)
+				return nil
+			}
+			fmt.Printf("Installed %s versions:\n", runtimeName)
+			for _, v := range state.InstalledVersions {
+				if v == state.ActiveVersion {
+					fmt.Printf(" * %s (active)\n", v)
+				} else {
+					fmt.Printf("   %s\n", v)
+				}
+			}
+			return nil
+		}
+
 		versions, err := internal.ListInstalledVersions(cfg)
 		if err != nil {
 			return err
@@ -152,8 +483,20 @@ var listRemoteCmd = &cobra.Command{
 			return fmt.Errorf("failed to fetch remote versions: %w", err)
 		}
 
+		lts, _ := cmd.Flags().GetString("lts")
+		major, _ := cmd.Flags().GetInt("major")
+		rangeSpec, _ := cmd.Flags().GetString("range")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		filtered := internal.FilterRemoteVersions(versions, internal.RemoteVersionFilter{
+			LTSCodename: lts,
+			Major:       major,
+			Range:       rangeSpec,
+			Limit:       limit,
+		})
+
 		fmt.Println("Available Node.js versions:")
-		for _, v := range versions {
+		for _, v := range filtered {
 			fmt.Printf("  %s\n", v.GetVersionString())
 		}
 		return nil
@@ -164,6 +507,16 @@ var currentCmd = &cobra.Command{
 	Use:   "current",
 	Short: "Display the currently active Node.js version",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		runtimeName, _ := cmd.Flags().GetString("runtime")
+		if runtimeName != "" && runtimeName != "node" {
+			version, err := internal.CurrentRuntime(runtimeName, cfg)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Currently active %s version: %s\n", runtimeName, version)
+			return nil
+		}
+
 		version, err := internal.GetActiveVersion(cfg)
 		if err != nil {
 			// Handle case where no version is active yet
@@ -223,6 +576,28 @@ var versionCmd = &cobra.Command{
 	},
 }
 
+var updateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update node-spark itself to the latest release",
+	Long: `Check node-spark's release manifest for a version newer than the one
+currently running, and if found, download, verify (SHA-256 checksum + ed25519
+signature), and atomically install it in place of the running executable.
+
+The previous binary is kept as "<executable>.old"; pass --rollback to
+restore it instead of checking for an update.
+
+Not yet available in this build: it refuses to run until a release signing
+key is bundled (see selfUpdatePublicKeyB64). --rollback still works.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if rollback, _ := cmd.Flags().GetBool("rollback"); rollback {
+			return internal.RollbackSelfUpdate()
+		}
+
+		channel, _ := cmd.Flags().GetString("channel")
+		return internal.SelfUpdate(version, channel, internal.RealUpdater())
+	},
+}
+
 // --- Global Installation Commands ---
 
 var installGlobalCmd = &cobra.Command{
@@ -234,6 +609,186 @@ var installGlobalCmd = &cobra.Command{
 	},
 }
 
+// --- Settings Commands ---
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Get or set node-spark network settings (mirrors, proxy, TLS verification)",
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a config value (node-mirror, npm-mirror, proxy, verify-ssl)",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		settingsPath := pkg.GetSettingsPath()
+		settings, err := pkg.LoadSettings(settingsPath)
+		if err != nil {
+			return err
+		}
+
+		if err := settings.Set(args[0], args[1]); err != nil {
+			return err
+		}
+
+		if err := pkg.SaveSettings(settingsPath, settings); err != nil {
+			return err
+		}
+
+		fmt.Printf("Set %s = %s\n", args[0], args[1])
+		return nil
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Get a config value (node-mirror, npm-mirror, proxy, verify-ssl)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		settings, err := pkg.LoadSettings(pkg.GetSettingsPath())
+		if err != nil {
+			return err
+		}
+
+		value, err := settings.Get(args[0])
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(value)
+		return nil
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "Print every configured network setting (mirrors, proxy, TLS verification)",
+	Aliases: []string{"ls"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		settings, err := pkg.LoadSettings(pkg.GetSettingsPath())
+		if err != nil {
+			return err
+		}
+
+		for _, key := range []string{"node-mirror", "npm-mirror", "proxy", "verify-ssl"} {
+			value, _ := settings.Get(key)
+			fmt.Printf("%s = %s\n", key, value)
+		}
+		return nil
+	},
+}
+
+// --- Package Manager Commands (npm, pnpm, yarn) ---
+
+// newPackageManagerCmd builds the "install"/"use"/"current" command tree for
+// a single package manager tool, storing it under
+// ~/.node-spark/pm/<tool>/<version> independent of any Node.js install (see
+// internal/packagemanager.go). npm, pnpm, and yarn all get an identical
+// tree, so it's built once and instantiated per tool rather than
+// copy-pasted three times.
+func newPackageManagerCmd(tool string) *cobra.Command {
+	toolCmd := &cobra.Command{
+		Use:   tool,
+		Short: fmt.Sprintf("Manage %s versions, shimmed into the active Node.js installation", tool),
+	}
+
+	toolCmd.AddCommand(&cobra.Command{
+		Use:   "install <version|latest>",
+		Short: fmt.Sprintf("Install a %s version", tool),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := internal.InstallPackageManager(tool, args[0], cfg); err != nil {
+				return err
+			}
+			return pkg.SaveConfig(cfgPath, cfg)
+		},
+	})
+
+	toolCmd.AddCommand(&cobra.Command{
+		Use:   "use <version>",
+		Short: fmt.Sprintf("Use an installed %s version", tool),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := internal.UsePackageManager(tool, args[0], cfg); err != nil {
+				return err
+			}
+			return pkg.SaveConfig(cfgPath, cfg)
+		},
+	})
+
+	toolCmd.AddCommand(&cobra.Command{
+		Use:   "current",
+		Short: fmt.Sprintf("Display the active %s version", tool),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, err := internal.CurrentPackageManager(tool, cfg)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Current %s version: %s\n", tool, version)
+			return nil
+		},
+	})
+
+	return toolCmd
+}
+
+var npmCmd = newPackageManagerCmd("npm")
+var pnpmCmd = newPackageManagerCmd("pnpm")
+var yarnCmd = newPackageManagerCmd("yarn")
+
+// --- Alias Commands ---
+
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage named aliases for Node.js versions (e.g. lts/hydrogen)",
+}
+
+var aliasSetCmd = &cobra.Command{
+	Use:   "set <name> <version>",
+	Short: "Point an alias at a concrete Node.js version",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cfg.Aliases == nil {
+			cfg.Aliases = make(map[string]string)
+		}
+		cfg.Aliases[args[0]] = args[1]
+		fmt.Printf("Alias %s -> %s\n", args[0], args[1])
+		return pkg.SaveConfig(cfgPath, cfg)
+	},
+}
+
+var aliasLsCmd = &cobra.Command{
+	Use:     "ls",
+	Short:   "List all configured aliases",
+	Aliases: []string{"list"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(cfg.Aliases) == 0 {
+			fmt.Println("No aliases configured.")
+			return nil
+		}
+		for name, version := range cfg.Aliases {
+			fmt.Printf("  %s -> %s\n", name, version)
+		}
+		return nil
+	},
+}
+
+var aliasRmCmd = &cobra.Command{
+	Use:     "rm <name>",
+	Short:   "Remove an alias",
+	Aliases: []string{"remove", "delete"},
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, ok := cfg.Aliases[args[0]]; !ok {
+			return fmt.Errorf("no such alias: %s", args[0])
+		}
+		delete(cfg.Aliases, args[0])
+		fmt.Printf("Removed alias %s\n", args[0])
+		return pkg.SaveConfig(cfgPath, cfg)
+	},
+}
+
 var uninstallGlobalCmd = &cobra.Command{
 	Use:     "uninstall-global",
 	Short:   "Uninstall node-spark from your system",
@@ -0,0 +1,40 @@
+// internal/alias.go
+
+package internal
+
+import (
+	"sort"
+
+	"github.com/s42yt/node-spark/pkg"
+)
+
+// SystemAlias is the version spec nvm calls "system": instead of resolving
+// to a managed Node.js install, it deactivates node-spark's symlink/junction
+// so the OS's own Node.js (whatever's next on PATH) takes over. It's
+// recognized by ResolveVersionSpec and handled by UseVersion.
+const SystemAlias = "system"
+
+// AliasBadgesFor returns the alias names that currently resolve to version:
+// the built-in "latest" alias when version is the newest installed release,
+// plus any user-defined config.Aliases entries pointing at it. Used by the
+// TUI to annotate the version list.
+func AliasBadgesFor(version string, config *pkg.Config) []string {
+	var badges []string
+
+	if newest := newestVersion(config.InstalledVersions); newest != "" && newest == version {
+		badges = append(badges, "latest")
+	}
+
+	names := make([]string, 0, len(config.Aliases))
+	for name := range config.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if config.Aliases[name] == version {
+			badges = append(badges, name)
+		}
+	}
+
+	return badges
+}
@@ -0,0 +1,219 @@
+// internal/arch.go
+
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/s42yt/node-spark/pkg"
+)
+
+// configMu serializes mutations to config.InstalledVersions/InstalledArches
+// (via recordInstalledArch), the only state InstallVersions' concurrent
+// workers (install_batch.go) write to. Uncontended in every other call path,
+// so it costs nothing outside of batch installs.
+var configMu sync.Mutex
+
+// NormalizeArch maps a user-supplied architecture (a Go GOARCH name like
+// "amd64"/"aarch64" or a Node.js dist arch name) to the canonical Node.js
+// arch name used in archive filenames and the on-disk layout
+// (installPath/<version>/<arch>), following nvm-windows' procarch handling.
+func NormalizeArch(arch string) (string, error) {
+	switch strings.ToLower(arch) {
+	case "x64", "amd64":
+		return "x64", nil
+	case "x86", "386", "ia32":
+		return "x86", nil
+	case "arm64", "aarch64":
+		return "arm64", nil
+	case "arm", "armv7l":
+		return "armv7l", nil
+	default:
+		return "", fmt.Errorf("unsupported architecture %q (expected x64, x86, arm64, or armv7l)", arch)
+	}
+}
+
+// defaultNodeArch returns the canonical Node.js arch name for the
+// architecture node-spark itself is running as.
+func defaultNodeArch() string {
+	arch, err := NormalizeArch(runtime.GOARCH)
+	if err != nil {
+		return "x64"
+	}
+	return arch
+}
+
+// stringSliceContains reports whether v is present in s.
+func stringSliceContains(s []string, v string) bool {
+	for _, item := range s {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+// installedArches returns the architectures config has recorded as
+// installed for version (cleaned of any "v" prefix). Guarded by configMu
+// since InstallVersions' concurrent workers call this (via
+// InstallNodeVersion/resolveVersionArchPath) while sibling workers are
+// writing the same map through recordInstalledArch.
+func installedArches(config *pkg.Config, version string) []string {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	if config.InstalledArches == nil {
+		return nil
+	}
+	return config.InstalledArches[version]
+}
+
+// recordInstalledArch marks version+arch as installed in config, alongside
+// the existing arch-agnostic InstalledVersions bookkeeping.
+func recordInstalledArch(config *pkg.Config, version, arch string) {
+	configMu.Lock()
+	defer configMu.Unlock()
+
+	if config.InstalledArches == nil {
+		config.InstalledArches = make(map[string][]string)
+	}
+	if !stringSliceContains(config.InstalledArches[version], arch) {
+		config.InstalledArches[version] = append(config.InstalledArches[version], arch)
+	}
+	if !stringSliceContains(config.InstalledVersions, version) {
+		config.InstalledVersions = append(config.InstalledVersions, version)
+	}
+}
+
+// migrateFlatVersionDir upgrades a pre-multi-arch install of version from the
+// old layout (installPath/<version>, containing the extracted archive
+// directly) to the new one (installPath/<version>/<arch>), assuming the
+// existing install is for the architecture node-spark is currently running
+// as - the only architecture that could have been installed before this
+// layout existed. It's a no-op if the flat directory doesn't exist or has
+// already been migrated, and it also backfills config.InstalledArches for
+// versions the old config.json predates.
+func migrateFlatVersionDir(config *pkg.Config, version string) error {
+	versionRoot := filepath.Join(pkg.GetInstallPath(config), version)
+
+	entries, err := os.ReadDir(versionRoot)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to inspect %s: %w", versionRoot, err)
+	}
+
+	// Already migrated (or never installed flat) if every entry is itself a
+	// directory whose name we recognize as an arch.
+	flat := false
+	for _, e := range entries {
+		if !e.IsDir() {
+			flat = true
+			break
+		}
+		if _, err := NormalizeArch(e.Name()); err != nil {
+			flat = true
+			break
+		}
+	}
+	if !flat {
+		if len(installedArches(config, version)) == 0 {
+			for _, e := range entries {
+				recordInstalledArch(config, version, e.Name())
+			}
+		}
+		return nil
+	}
+
+	arch := defaultNodeArch()
+	archDir := filepath.Join(versionRoot, arch)
+	tmpDir := versionRoot + ".migrating"
+
+	if err := os.Rename(versionRoot, tmpDir); err != nil {
+		return fmt.Errorf("failed to migrate %s to the per-architecture layout: %w", versionRoot, err)
+	}
+	if err := os.MkdirAll(versionRoot, 0755); err != nil {
+		return fmt.Errorf("failed to recreate %s: %w", versionRoot, err)
+	}
+	if err := os.Rename(tmpDir, archDir); err != nil {
+		return fmt.Errorf("failed to migrate %s to the per-architecture layout: %w", versionRoot, err)
+	}
+
+	recordInstalledArch(config, version, arch)
+	fmt.Printf("Migrated Node.js %s to the per-architecture install layout (%s).\n", version, arch)
+	return nil
+}
+
+// resolveVersionArchPath picks which installed architecture's directory to
+// activate for version, honoring an explicit archOverride when given and
+// otherwise preferring the architecture node-spark itself is running as. In
+// auto mode (archOverride == ""), if the preferred build isn't runnable here
+// (IsProperArchForSystem), it falls back to any other installed architecture
+// that is - e.g. an x64 build on Apple Silicon running under Rosetta, or
+// vice versa - following nvm-windows' procarch handling.
+func resolveVersionArchPath(config *pkg.Config, version, archOverride string) (arch, versionPath string, err error) {
+	if err := migrateFlatVersionDir(config, version); err != nil {
+		return "", "", err
+	}
+
+	installed := installedArches(config, version)
+	if len(installed) == 0 {
+		return "", "", fmt.Errorf("version %s is not installed", version)
+	}
+
+	auto := archOverride == ""
+	if auto {
+		arch = defaultNodeArch()
+		if !stringSliceContains(installed, arch) {
+			arch = installed[0]
+		}
+	} else {
+		arch, err = NormalizeArch(archOverride)
+		if err != nil {
+			return "", "", err
+		}
+		if !stringSliceContains(installed, arch) {
+			return "", "", fmt.Errorf("Node.js %s is not installed for %s (installed: %s)", version, arch, strings.Join(installed, ", "))
+		}
+	}
+
+	versionRoot := filepath.Join(pkg.GetInstallPath(config), version)
+	versionPath = filepath.Join(versionRoot, arch)
+
+	if auto && !IsProperArchForSystem(nodeBinaryPath(versionPath)) {
+		for _, candidate := range installed {
+			if candidate == arch {
+				continue
+			}
+			candidatePath := filepath.Join(versionRoot, candidate)
+			if IsProperArchForSystem(nodeBinaryPath(candidatePath)) {
+				fmt.Printf("Warning: the %s build of Node.js %s doesn't run on this system; falling back to %s.\n", arch, version, candidate)
+				arch = candidate
+				versionPath = candidatePath
+				break
+			}
+		}
+	}
+
+	return arch, versionPath, nil
+}
+
+// nodeBinaryPath returns the expected node executable path inside an
+// installed version/arch directory, trying both the flat layout some
+// distributions use and the bin/ subdirectory.
+func nodeBinaryPath(versionPath string) string {
+	name := "node"
+	if runtime.GOOS == "windows" {
+		name = "node.exe"
+	}
+	if _, err := os.Stat(filepath.Join(versionPath, name)); err == nil {
+		return filepath.Join(versionPath, name)
+	}
+	return filepath.Join(versionPath, "bin", name)
+}
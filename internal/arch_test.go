@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/s42yt/node-spark/pkg"
+)
+
+func TestResolveVersionArchPath(t *testing.T) {
+	cases := []struct {
+		name         string
+		installed    map[string][]string
+		version      string
+		archOverride string
+		wantArch     string
+		wantErr      bool
+	}{
+		{
+			name:      "version not installed",
+			installed: map[string][]string{},
+			version:   "20.11.0",
+			wantErr:   true,
+		},
+		{
+			name:         "invalid arch override",
+			installed:    map[string][]string{"20.11.0": {"x64"}},
+			version:      "20.11.0",
+			archOverride: "sparc",
+			wantErr:      true,
+		},
+		{
+			name:         "arch override not installed for this version",
+			installed:    map[string][]string{"20.11.0": {"arm64"}},
+			version:      "20.11.0",
+			archOverride: "x64",
+			wantErr:      true,
+		},
+		{
+			name:         "arch override installed",
+			installed:    map[string][]string{"20.11.0": {"x64", "arm64"}},
+			version:      "20.11.0",
+			archOverride: "arm64",
+			wantArch:     "arm64",
+		},
+		{
+			name:      "auto mode falls back to the only installed arch",
+			installed: map[string][]string{"20.11.0": {"armv7l"}},
+			version:   "20.11.0",
+			wantArch:  "armv7l",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &pkg.Config{
+				InstallPath:     t.TempDir(),
+				InstalledArches: tc.installed,
+			}
+
+			arch, versionPath, err := resolveVersionArchPath(config, tc.version, tc.archOverride)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("resolveVersionArchPath(%q, %q) = %q, %q, nil; want error", tc.version, tc.archOverride, arch, versionPath)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveVersionArchPath(%q, %q) returned unexpected error: %v", tc.version, tc.archOverride, err)
+			}
+			if arch != tc.wantArch {
+				t.Errorf("arch = %q, want %q", arch, tc.wantArch)
+			}
+			wantPath := filepath.Join(config.InstallPath, tc.version, tc.wantArch)
+			if versionPath != wantPath {
+				t.Errorf("versionPath = %q, want %q", versionPath, wantPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// internal/autoswitch.go
+
+package internal
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/s42yt/node-spark/pkg"
+)
+
+// DefaultVersionDetectors returns the built-in detector chain, tried in the
+// same precedence nvm/nenv use: an explicit .nvmrc first, then
+// .node-version, then package.json's engines.node.
+func DefaultVersionDetectors() []pkg.VersionDetector {
+	return []pkg.VersionDetector{
+		pkg.NvmrcDetector{},
+		pkg.NodeVersionDetector{},
+		pkg.PackageEnginesDetector{},
+	}
+}
+
+// DetectProjectVersionSpec walks up from startDir, running detectors against
+// each directory in turn, and returns the first non-empty spec found along
+// with the name of the detector it came from. FindProjectVersionSpec in
+// resolver.go is the concrete .nvmrc/.node-version/engines.node entry point
+// most callers use; this is the pluggable version behind it, for callers
+// (or future detectors) that want a different chain.
+func DetectProjectVersionSpec(startDir string, detectors []pkg.VersionDetector) (spec string, source string, err error) {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to resolve working directory: %w", err)
+	}
+
+	for {
+		for _, d := range detectors {
+			found, err := d.Detect(dir)
+			if err != nil {
+				return "", "", fmt.Errorf("%s: %w", d.Name(), err)
+			}
+			if found != "" {
+				return found, d.Name(), nil
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	return "", "", nil
+}
+
+// detectPackageManagerSpec walks up from startDir the same way
+// DetectProjectVersionSpec does, looking for package.json's corepack-style
+// "packageManager" field (e.g. "pnpm@8.6.0").
+func detectPackageManagerSpec(startDir string) (pkg.PackageManagerSpec, error) {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return pkg.PackageManagerSpec{}, fmt.Errorf("failed to resolve working directory: %w", err)
+	}
+
+	for {
+		spec, err := pkg.ReadPackageManagerField(dir)
+		if err != nil {
+			return pkg.PackageManagerSpec{}, err
+		}
+		if spec.Tool != "" {
+			return spec, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	return pkg.PackageManagerSpec{}, nil
+}
+
+// ResolveAndActivate detects the project version spec from cwd using
+// DefaultVersionDetectors, resolves it against installed (or, with
+// installMissing, remote) versions, and switches the active version to
+// match. If package.json also names a "packageManager" (corepack-style), it
+// activates that too, alongside Node. It backs both the "auto" and
+// "resolve" commands; when silent is true (used by the cd/chpwd shell hook,
+// where stdout would otherwise print on every prompt) it suppresses the
+// progress messages a manually-run "nsk auto" prints.
+func ResolveAndActivate(cwd string, config *pkg.Config, installMissing, silent bool) error {
+	spec, source, err := DetectProjectVersionSpec(cwd, DefaultVersionDetectors())
+	if err != nil {
+		return err
+	}
+	if spec == "" {
+		if silent {
+			return nil
+		}
+		return fmt.Errorf("no .nvmrc/.node-version/package.json engines.node found")
+	}
+
+	version, err := ResolveVersionSpec(spec, config, installMissing)
+	if err != nil {
+		if silent {
+			return nil
+		}
+		return err
+	}
+
+	if !silent {
+		fmt.Printf("Resolved %s (from %s) to Node.js %s\n", spec, source, version)
+	}
+
+	if silent {
+		wasQuiet := quietOutput
+		SetQuiet(true)
+		defer SetQuiet(wasQuiet)
+	}
+
+	if config.ActiveVersion != version {
+		// The shell hook feeds off the persistent "current" symlink/junction,
+		// so auto-switching (unlike a plain "nsk use") always activates
+		// globally.
+		if err := SetActiveVersion(version, "", config, true); err != nil {
+			return err
+		}
+	}
+
+	pmSpec, err := detectPackageManagerSpec(cwd)
+	if err != nil {
+		if silent {
+			return nil
+		}
+		return err
+	}
+	if pmSpec.Tool == "" {
+		return nil
+	}
+
+	if err := ActivatePackageManager(pmSpec.Tool, pmSpec.Version, config, installMissing); err != nil {
+		if silent {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+// internal/distribution.go
+
+package internal
+
+import (
+	"fmt"
+
+	"github.com/s42yt/node-spark/pkg"
+)
+
+// Distribution abstracts where a runtime's version index and archives come
+// from, so the same download/verify/extract pipeline can serve the official
+// release channel as well as nightlies, release candidates, and unofficial
+// builds without InstallNodeVersion knowing which one it's talking to.
+type Distribution interface {
+	// Name identifies the distribution for --dist and display purposes.
+	Name() string
+	// IndexURL returns the version index URL for this distribution.
+	IndexURL(settings *pkg.Settings) string
+	// ArchiveURL returns the download URL for a specific version/os/arch build.
+	ArchiveURL(settings *pkg.Settings, versionStr, nodeOS, nodeArch, ext string) string
+	// ChecksumURL returns the SHASUMS256.txt URL for a specific version.
+	ChecksumURL(settings *pkg.Settings, versionStr string) string
+	// SignatureURL returns the detached OpenPGP signature URL for the
+	// SHASUMS256.txt manifest at ChecksumURL.
+	SignatureURL(settings *pkg.Settings, versionStr string) string
+}
+
+// releaseDistribution serves the official nodejs.org release channel.
+type releaseDistribution struct{}
+
+func (releaseDistribution) Name() string { return "release" }
+
+func (releaseDistribution) IndexURL(settings *pkg.Settings) string {
+	return NodeDistBaseURL(settings) + "/index.json"
+}
+
+func (releaseDistribution) ArchiveURL(settings *pkg.Settings, versionStr, nodeOS, nodeArch, ext string) string {
+	filename := fmt.Sprintf("node-%s-%s-%s.%s", versionStr, nodeOS, nodeArch, ext)
+	return fmt.Sprintf("%s/%s/%s", NodeDistBaseURL(settings), versionStr, filename)
+}
+
+func (releaseDistribution) ChecksumURL(settings *pkg.Settings, versionStr string) string {
+	return fmt.Sprintf("%s/%s/SHASUMS256.txt", NodeDistBaseURL(settings), versionStr)
+}
+
+func (releaseDistribution) SignatureURL(settings *pkg.Settings, versionStr string) string {
+	return releaseDistribution{}.ChecksumURL(settings, versionStr) + ".sig"
+}
+
+// nightlyDistribution serves nodejs.org's nightly builds.
+type nightlyDistribution struct{}
+
+func (nightlyDistribution) Name() string { return "nightly" }
+
+func (nightlyDistribution) IndexURL(settings *pkg.Settings) string {
+	return NodeDistBaseURL(settings) + "/download/nightly/index.json"
+}
+
+func (nightlyDistribution) ArchiveURL(settings *pkg.Settings, versionStr, nodeOS, nodeArch, ext string) string {
+	filename := fmt.Sprintf("node-%s-%s-%s.%s", versionStr, nodeOS, nodeArch, ext)
+	return fmt.Sprintf("%s/download/nightly/%s/%s", NodeDistBaseURL(settings), versionStr, filename)
+}
+
+func (nightlyDistribution) ChecksumURL(settings *pkg.Settings, versionStr string) string {
+	return fmt.Sprintf("%s/download/nightly/%s/SHASUMS256.txt", NodeDistBaseURL(settings), versionStr)
+}
+
+func (nightlyDistribution) SignatureURL(settings *pkg.Settings, versionStr string) string {
+	return nightlyDistribution{}.ChecksumURL(settings, versionStr) + ".sig"
+}
+
+// rcDistribution serves nodejs.org's release-candidate builds.
+type rcDistribution struct{}
+
+func (rcDistribution) Name() string { return "rc" }
+
+func (rcDistribution) IndexURL(settings *pkg.Settings) string {
+	return NodeDistBaseURL(settings) + "/download/rc/index.json"
+}
+
+func (rcDistribution) ArchiveURL(settings *pkg.Settings, versionStr, nodeOS, nodeArch, ext string) string {
+	filename := fmt.Sprintf("node-%s-%s-%s.%s", versionStr, nodeOS, nodeArch, ext)
+	return fmt.Sprintf("%s/download/rc/%s/%s", NodeDistBaseURL(settings), versionStr, filename)
+}
+
+func (rcDistribution) ChecksumURL(settings *pkg.Settings, versionStr string) string {
+	return fmt.Sprintf("%s/download/rc/%s/SHASUMS256.txt", NodeDistBaseURL(settings), versionStr)
+}
+
+func (rcDistribution) SignatureURL(settings *pkg.Settings, versionStr string) string {
+	return rcDistribution{}.ChecksumURL(settings, versionStr) + ".sig"
+}
+
+// unofficialDistribution serves unofficial-builds.nodejs.org, which covers
+// platforms the official release channel drops over time (e.g. musl, armv6).
+type unofficialDistribution struct{}
+
+func (unofficialDistribution) Name() string { return "unofficial" }
+
+func (unofficialDistribution) IndexURL(settings *pkg.Settings) string {
+	return "https://unofficial-builds.nodejs.org/download/release/index.json"
+}
+
+func (unofficialDistribution) ArchiveURL(settings *pkg.Settings, versionStr, nodeOS, nodeArch, ext string) string {
+	filename := fmt.Sprintf("node-%s-%s-%s.%s", versionStr, nodeOS, nodeArch, ext)
+	return fmt.Sprintf("https://unofficial-builds.nodejs.org/download/release/%s/%s", versionStr, filename)
+}
+
+func (unofficialDistribution) ChecksumURL(settings *pkg.Settings, versionStr string) string {
+	return fmt.Sprintf("https://unofficial-builds.nodejs.org/download/release/%s/SHASUMS256.txt", versionStr)
+}
+
+func (unofficialDistribution) SignatureURL(settings *pkg.Settings, versionStr string) string {
+	return unofficialDistribution{}.ChecksumURL(settings, versionStr) + ".sig"
+}
+
+// distributions is the registry of known distributions, keyed by the name
+// passed to --dist.
+var distributions = map[string]Distribution{
+	"release":    releaseDistribution{},
+	"nightly":    nightlyDistribution{},
+	"rc":         rcDistribution{},
+	"unofficial": unofficialDistribution{},
+}
+
+// GetDistribution looks up a distribution by name, defaulting to "release"
+// when name is empty.
+func GetDistribution(name string) (Distribution, error) {
+	if name == "" {
+		name = "release"
+	}
+	dist, ok := distributions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown distribution %q (available: release, nightly, rc, unofficial)", name)
+	}
+	return dist, nil
+}
+
+// activeDistributionName is set via SetDistribution (the --dist flag) and
+// consulted by InstallNodeVersion and FetchAvailableVersions.
+var activeDistributionName = "release"
+
+// SetDistribution selects the distribution used by subsequent installs and
+// version listings. An empty name resets to "release".
+func SetDistribution(name string) error {
+	if _, err := GetDistribution(name); err != nil {
+		return err
+	}
+	if name == "" {
+		name = "release"
+	}
+	activeDistributionName = name
+	return nil
+}
+
+// ActiveDistribution returns the currently selected distribution.
+func ActiveDistribution() Distribution {
+	dist, _ := GetDistribution(activeDistributionName)
+	return dist
+}
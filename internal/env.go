@@ -0,0 +1,149 @@
+// internal/env.go
+
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/s42yt/node-spark/pkg"
+)
+
+// nodeBinDir returns the directory inside versionPath that holds the node
+// executable: "<versionPath>/bin" on POSIX archives, or versionPath itself
+// when there's no bin subdirectory (Windows archives place node.exe at the
+// archive root), mirroring the fallback useVersionWindowsShims already uses.
+func nodeBinDir(versionPath string) string {
+	binPath := filepath.Join(versionPath, "bin")
+	if _, err := os.Stat(binPath); err == nil {
+		return binPath
+	}
+	return versionPath
+}
+
+// posixActivateTemplate covers both bash and zsh, which share syntax for
+// this. Re-activating in a shell that's already activated restores PATH
+// from NODE_SPARK_PREV_PATH first, so switching versions in one session
+// doesn't stack bin directories.
+const posixActivateTemplate = `# node-spark activation ({{SHELL}})
+if [ -n "${NODE_SPARK_PREV_PATH:-}" ]; then
+  PATH="$NODE_SPARK_PREV_PATH"
+else
+  export NODE_SPARK_PREV_PATH="$PATH"
+fi
+export PATH="{{BIN}}:$PATH"
+export NODE_SPARK_ACTIVE_VERSION="{{VERSION}}"
+`
+
+const fishActivateTemplate = `# node-spark activation (fish)
+if set -q NODE_SPARK_PREV_PATH
+  set -gx PATH $NODE_SPARK_PREV_PATH
+else
+  set -gx NODE_SPARK_PREV_PATH $PATH
+end
+set -gx PATH {{BIN}} $PATH
+set -gx NODE_SPARK_ACTIVE_VERSION {{VERSION}}
+`
+
+const powershellActivateTemplate = `# node-spark activation (powershell)
+if ($env:NODE_SPARK_PREV_PATH) {
+  $env:Path = $env:NODE_SPARK_PREV_PATH
+} else {
+  $env:NODE_SPARK_PREV_PATH = $env:Path
+}
+$env:Path = "{{BIN}};" + $env:Path
+$env:NODE_SPARK_ACTIVE_VERSION = "{{VERSION}}"
+`
+
+const cmdActivateTemplate = `@echo off
+if defined NODE_SPARK_PREV_PATH (
+  set "PATH=%NODE_SPARK_PREV_PATH%"
+) else (
+  set "NODE_SPARK_PREV_PATH=%PATH%"
+)
+set "PATH={{BIN}};%PATH%"
+set "NODE_SPARK_ACTIVE_VERSION={{VERSION}}"
+`
+
+const posixDeactivateTemplate = `# node-spark deactivation ({{SHELL}})
+if [ -n "${NODE_SPARK_PREV_PATH:-}" ]; then
+  export PATH="$NODE_SPARK_PREV_PATH"
+  unset NODE_SPARK_PREV_PATH
+fi
+unset NODE_SPARK_ACTIVE_VERSION
+`
+
+const fishDeactivateTemplate = `# node-spark deactivation (fish)
+if set -q NODE_SPARK_PREV_PATH
+  set -gx PATH $NODE_SPARK_PREV_PATH
+  set -e NODE_SPARK_PREV_PATH
+end
+set -e NODE_SPARK_ACTIVE_VERSION
+`
+
+const powershellDeactivateTemplate = `# node-spark deactivation (powershell)
+if ($env:NODE_SPARK_PREV_PATH) {
+  $env:Path = $env:NODE_SPARK_PREV_PATH
+  Remove-Item Env:NODE_SPARK_PREV_PATH
+}
+Remove-Item Env:NODE_SPARK_ACTIVE_VERSION -ErrorAction SilentlyContinue
+`
+
+const cmdDeactivateTemplate = `@echo off
+if defined NODE_SPARK_PREV_PATH (
+  set "PATH=%NODE_SPARK_PREV_PATH%"
+  set "NODE_SPARK_PREV_PATH="
+)
+set "NODE_SPARK_ACTIVE_VERSION="
+`
+
+// renderEnvScript substitutes {{SHELL}}, {{BIN}}, and {{VERSION}} into tmpl.
+func renderEnvScript(tmpl, shell, binDir, version string) string {
+	r := strings.NewReplacer("{{SHELL}}", shell, "{{BIN}}", binDir, "{{VERSION}}", version)
+	return r.Replace(tmpl)
+}
+
+// GenerateActivationScript returns a shell script that prepends version's bin
+// directory to PATH for the current shell session only (see the "env"
+// command), saving the pre-activation PATH in NODE_SPARK_PREV_PATH so
+// GenerateDeactivationScript can restore it later.
+func GenerateActivationScript(shell, version string, config *pkg.Config) (string, error) {
+	_, versionPath, err := resolveVersionArchPath(config, version, "")
+	if err != nil {
+		return "", err
+	}
+	binDir := nodeBinDir(versionPath)
+
+	switch shell {
+	case "bash", "zsh":
+		return renderEnvScript(posixActivateTemplate, shell, binDir, version), nil
+	case "fish":
+		return renderEnvScript(fishActivateTemplate, shell, binDir, version), nil
+	case "powershell":
+		return renderEnvScript(powershellActivateTemplate, shell, binDir, version), nil
+	case "cmd":
+		return renderEnvScript(cmdActivateTemplate, shell, binDir, version), nil
+	default:
+		return "", fmt.Errorf("unsupported shell %q (supported: bash, zsh, fish, powershell, cmd)", shell)
+	}
+}
+
+// GenerateDeactivationScript returns a shell script that undoes
+// GenerateActivationScript: restores PATH from NODE_SPARK_PREV_PATH and
+// clears the node-spark session env vars.
+func GenerateDeactivationScript(shell string) (string, error) {
+	switch shell {
+	case "bash", "zsh":
+		return renderEnvScript(posixDeactivateTemplate, shell, "", ""), nil
+	case "fish":
+		return fishDeactivateTemplate, nil
+	case "powershell":
+		return powershellDeactivateTemplate, nil
+	case "cmd":
+		return cmdDeactivateTemplate, nil
+	default:
+		return "", fmt.Errorf("unsupported shell %q (supported: bash, zsh, fish, powershell, cmd)", shell)
+	}
+}
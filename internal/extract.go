@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 )
 
 // ExtractArchive extracts a downloaded archive (.tar.gz or .zip) to a destination directory.
@@ -18,23 +19,26 @@ func ExtractArchive(archivePath string, destPath string) error {
 	ext := filepath.Ext(archivePath)
 
 	if ext == ".gz" && strings.HasSuffix(strings.TrimSuffix(archivePath, ext), ".tar") {
-		return extractTarGz(archivePath, destPath)
+		file, err := os.Open(archivePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		return extractTarGz(file, destPath)
 	} else if ext == ".zip" {
+		// Zip requires random access (io.ReaderAt), so unlike tar.gz it can't
+		// stream directly off an arbitrary io.Reader and is always read from disk.
 		return extractZip(archivePath, destPath)
 	} else {
 		return fmt.Errorf("unsupported archive format: %s", ext)
 	}
 }
 
-// extractTarGz extracts a .tar.gz file
-func extractTarGz(archivePath string, destPath string) error {
-	file, err := os.Open(archivePath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	gzipReader, err := gzip.NewReader(file)
+// extractTarGz extracts a .tar.gz stream, read directly from r. This lets
+// callers chain extraction straight off an HTTP response body without
+// staging the archive to disk first.
+func extractTarGz(r io.Reader, destPath string) error {
+	gzipReader, err := gzip.NewReader(r)
 	if err != nil {
 		return err
 	}
@@ -57,7 +61,17 @@ func extractTarGz(archivePath string, destPath string) error {
 		if len(parts) < 2 {
 			continue // Skip top-level directory entry itself or empty names
 		}
+
+		// Zip Slip guard: reject any entry whose cleaned target would land
+		// outside destPath (e.g. "../../etc/passwd" or an absolute path).
 		target := filepath.Join(destPath, parts[1])
+		cleanedDest := filepath.Clean(destPath)
+		cleanedTarget := filepath.Clean(target)
+		if cleanedTarget != cleanedDest && !strings.HasPrefix(cleanedTarget, cleanedDest+string(os.PathSeparator)) {
+			fmt.Printf("Warning: Skipping potentially unsafe tar entry: %s\n", header.Name)
+			continue
+		}
+		target = cleanedTarget
 
 		switch header.Typeflag {
 		case tar.TypeDir:
@@ -79,6 +93,15 @@ func extractTarGz(archivePath string, destPath string) error {
 			}
 			outFile.Close()
 		case tar.TypeSymlink:
+			// Zip Slip guard, part two: a symlink can pass the target check
+			// above yet still point itself outside destPath (an absolute
+			// linkname, or a relative one laden with "../"), so validate
+			// where it resolves to before creating it.
+			if !symlinkTargetSafe(cleanedDest, target, header.Linkname) {
+				fmt.Printf("Warning: Skipping tar symlink with unsafe target: %s -> %s\n", header.Name, header.Linkname)
+				continue
+			}
+
 			// Ensure parent directory exists
 			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
 				return err
@@ -102,7 +125,101 @@ func extractTarGz(archivePath string, destPath string) error {
 	return nil
 }
 
-// extractZip extracts a .zip file
+// symlinkTargetSafe reports whether a symlink at target (itself already
+// confirmed to be inside cleanedDest) may be created pointing at linkname
+// without letting it resolve outside cleanedDest: an absolute linkname
+// escapes immediately, and a relative one is resolved against target's
+// directory the same way the OS would follow it.
+func symlinkTargetSafe(cleanedDest, target, linkname string) bool {
+	if linkname == "" {
+		return false
+	}
+	if filepath.IsAbs(linkname) {
+		return false
+	}
+
+	resolved := filepath.Clean(filepath.Join(filepath.Dir(target), linkname))
+	return resolved == cleanedDest || strings.HasPrefix(resolved, cleanedDest+string(os.PathSeparator))
+}
+
+// extractionJobOverride, when set via SetExtractionJobs (the --jobs flag),
+// takes precedence over the runtime.NumCPU() default for parallel extraction.
+var extractionJobOverride int
+
+// SetExtractionJobs overrides the worker pool size used by extractZip.
+// Passing 0 or a negative value restores the runtime.NumCPU() default.
+func SetExtractionJobs(jobs int) {
+	extractionJobOverride = jobs
+}
+
+// extractionJobs returns the configured worker pool size for parallel extraction.
+func extractionJobs() int {
+	if extractionJobOverride > 0 {
+		return extractionJobOverride
+	}
+	return runtime.NumCPU()
+}
+
+// zipFileTask pairs a zip entry with its already-validated extraction target.
+type zipFileTask struct {
+	file   *zip.File
+	target string
+}
+
+// extractZipFilesConcurrently copies each task's zip entry to disk using a
+// bounded pool of jobs workers. Each worker opens its *zip.File independently,
+// which is safe for concurrent use.
+func extractZipFilesConcurrently(tasks []zipFileTask, jobs int) error {
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	sem := make(chan struct{}, jobs)
+	errCh := make(chan error, len(tasks))
+	var wg sync.WaitGroup
+
+	for _, t := range tasks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(t zipFileTask) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errCh <- extractSingleZipFile(t)
+		}(t)
+	}
+
+	wg.Wait()
+	close(errCh)
+
+	for err := range errCh {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractSingleZipFile writes one zip entry's contents to its target path.
+func extractSingleZipFile(t zipFileTask) error {
+	rc, err := t.file.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	outFile, err := os.OpenFile(t.target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, t.file.Mode())
+	if err != nil {
+		return err
+	}
+	defer outFile.Close()
+
+	_, err = io.Copy(outFile, rc)
+	return err
+}
+
+// extractZip extracts a .zip file, fanning file writes out across a bounded
+// worker pool (see SetExtractionJobs / extractionJobs) since each entry's
+// gzip decompression and disk write is independent of the others.
 func extractZip(archivePath string, destPath string) error {
 	zipReader, err := zip.OpenReader(archivePath)
 	if err != nil {
@@ -129,6 +246,25 @@ func extractZip(archivePath string, destPath string) error {
 
 	fmt.Printf("Detected top-level directory: %s\n", topLevelDir)
 
+	// createdDirs deduplicates MkdirAll calls across workers so concurrent
+	// files sharing a parent directory don't all redundantly stat/create it.
+	var createdDirs sync.Map
+	ensureDir := func(dir string, mode os.FileMode) error {
+		if _, ok := createdDirs.Load(dir); ok {
+			return nil
+		}
+		if err := os.MkdirAll(dir, mode); err != nil {
+			return err
+		}
+		createdDirs.Store(dir, struct{}{})
+		return nil
+	}
+
+	// First pass (sequential): resolve each entry's target path, apply the
+	// Zip Slip guard, and create directories up front so the worker pool
+	// below only ever needs to write files.
+	var tasks []zipFileTask
+
 	for _, file := range zipReader.File {
 		// Normalize path separators (some zips might use / even on Windows)
 		normalizedPath := filepath.FromSlash(file.Name)
@@ -156,46 +292,36 @@ func extractZip(archivePath string, destPath string) error {
 		cleanedTarget := filepath.Clean(targetPath)
 
 		// Ensure we're not extracting outside the destination directory (prevent Zip Slip)
-		if !strings.HasPrefix(cleanedTarget, filepath.Clean(destPath)) {
+		cleanedDest := filepath.Clean(destPath)
+		if cleanedTarget != cleanedDest && !strings.HasPrefix(cleanedTarget, cleanedDest+string(os.PathSeparator)) {
 			fmt.Printf("Warning: Skipping potentially unsafe path: %s\n", file.Name)
 			continue
 		}
 
 		if file.FileInfo().IsDir() {
-			if err := os.MkdirAll(cleanedTarget, file.Mode()); err != nil {
+			if err := ensureDir(cleanedTarget, file.Mode()); err != nil {
 				return err
 			}
 			continue
 		}
 
-		// Ensure parent directory exists
-		if err := os.MkdirAll(filepath.Dir(cleanedTarget), 0755); err != nil {
-			return err
-		}
-
 		// Skip if somehow the file path still ends up being a directory
 		if strings.HasSuffix(cleanedTarget, string(os.PathSeparator)) {
 			continue
 		}
 
-		outFile, err := os.OpenFile(cleanedTarget, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, file.Mode())
-		if err != nil {
+		if err := ensureDir(filepath.Dir(cleanedTarget), 0755); err != nil {
 			return err
 		}
 
-		rc, err := file.Open()
-		if err != nil {
-			outFile.Close()
-			return err
-		}
-
-		_, err = io.Copy(outFile, rc)
-		rc.Close()
-		outFile.Close()
+		tasks = append(tasks, zipFileTask{file: file, target: cleanedTarget})
+	}
 
-		if err != nil {
-			return err
-		}
+	// Second pass: fan out the actual file copies across a bounded worker
+	// pool. zip.Reader is safe for concurrent per-file Open() calls, so each
+	// worker opens its own entry independently.
+	if err := extractZipFilesConcurrently(tasks, extractionJobs()); err != nil {
+		return err
 	}
 
 	// Verify extraction was successful - for Windows, check if node.exe exists
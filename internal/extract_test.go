@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSymlinkTargetSafe(t *testing.T) {
+	dest := filepath.FromSlash("/tmp/node-spark-install/20.11.0/x64")
+	target := filepath.Join(dest, "bin", "npm")
+
+	cases := []struct {
+		name     string
+		linkname string
+		want     bool
+	}{
+		{"relative sibling stays inside dest", "npm-cli.js", true},
+		{"relative parent-of-file stays inside dest", "../lib/node_modules/npm/bin/npm-cli.js", true},
+		{"absolute linkname rejected", "/etc/passwd", false},
+		{"relative escape via .. rejected", "../../../../../etc/passwd", false},
+		{"empty linkname rejected", "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := symlinkTargetSafe(filepath.Clean(dest), target, tc.linkname); got != tc.want {
+				t.Errorf("symlinkTargetSafe(%q, %q, %q) = %v, want %v", dest, target, tc.linkname, got, tc.want)
+			}
+		})
+	}
+}
+
+// buildTestZip writes a zip archive containing n small files to a temp file
+// and returns its path.
+func buildTestZip(b *testing.B, n int) string {
+	b.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for i := 0; i < n; i++ {
+		w, err := zw.Create(filepath.ToSlash(filepath.Join("root", "file", "deep", "enough", "leaf")))
+		if err != nil {
+			b.Fatalf("failed to create zip entry: %v", err)
+		}
+		if _, err := w.Write(bytes.Repeat([]byte("x"), 1024)); err != nil {
+			b.Fatalf("failed to write zip entry: %v", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		b.Fatalf("failed to close zip writer: %v", err)
+	}
+
+	path := filepath.Join(b.TempDir(), "bench.zip")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		b.Fatalf("failed to write zip archive: %v", err)
+	}
+	return path
+}
+
+// BenchmarkExtractZipFilesConcurrently measures the parallel per-file
+// extraction worker pool (extractZipFilesConcurrently) writing many small
+// files to disk, the hot path SetExtractionJobs/--jobs tunes.
+func BenchmarkExtractZipFilesConcurrently(b *testing.B) {
+	const fileCount = 200
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		archivePath := buildTestZip(b, fileCount)
+		zr, err := zip.OpenReader(archivePath)
+		if err != nil {
+			b.Fatalf("failed to open zip archive: %v", err)
+		}
+		destDir := b.TempDir()
+		tasks := make([]zipFileTask, len(zr.File))
+		for j, f := range zr.File {
+			tasks[j] = zipFileTask{file: f, target: filepath.Join(destDir, filepath.Base(f.Name)+string(rune('a'+j%26)))}
+		}
+		b.StartTimer()
+
+		if err := extractZipFilesConcurrently(tasks, extractionJobs()); err != nil {
+			b.Fatalf("extractZipFilesConcurrently: %v", err)
+		}
+
+		b.StopTimer()
+		zr.Close()
+	}
+}
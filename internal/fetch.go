@@ -1,18 +1,66 @@
 package internal
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/s42yt/node-spark/pkg"
 )
 
+// indexCacheTTL is how long a cached copy of the remote version index is
+// trusted before FetchAvailableVersions re-fetches it. A stale cache is
+// still used as a last resort when the re-fetch itself fails (offline use).
+const indexCacheTTL = 6 * time.Hour
+
+// indexCachePath returns where the remote version index is cached:
+// ~/.node-spark/cache/index.json.
+func indexCachePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".node-spark", "cache", "index.json"), nil
+}
+
+// readIndexCache returns the cached index body if cachePath exists and,
+// when maxAge is positive, is no older than maxAge. Passing maxAge <= 0
+// accepts the cache regardless of age, for the offline fallback.
+func readIndexCache(cachePath string, maxAge time.Duration) ([]byte, bool) {
+	info, err := os.Stat(cachePath)
+	if err != nil {
+		return nil, false
+	}
+	if maxAge > 0 && time.Since(info.ModTime()) > maxAge {
+		return nil, false
+	}
+	body, err := os.ReadFile(cachePath)
+	if err != nil {
+		return nil, false
+	}
+	return body, true
+}
+
+// writeIndexCache best-effort persists body to cachePath; a failure here
+// (e.g. a read-only home directory) shouldn't fail the fetch that produced it.
+func writeIndexCache(cachePath string, body []byte) {
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(cachePath, body, 0644)
+}
+
 // NodeVersion represents a version entry from the Node.js index.
 type NodeVersion struct {
 	Version string      `json:"version"`
@@ -24,18 +72,58 @@ type NodeVersion struct {
 	V8      string      `json:"v8"`
 }
 
-// FetchAvailableVersions fetches the list of available Node.js versions.
+// FetchAvailableVersions fetches the list of available Node.js versions,
+// preferring a cached copy of the index under indexCacheTTL and falling back
+// to it (regardless of age) if the network fetch fails, so `nsk list-remote`
+// and alias resolution (`latest`, `lts`, ...) keep working offline.
 func FetchAvailableVersions() ([]NodeVersion, error) {
-	fmt.Println("Fetching Node.js versions from https://nodejs.org/dist/index.json...")
+	cachePath, cacheErr := indexCachePath()
+	if cacheErr == nil {
+		if body, ok := readIndexCache(cachePath, indexCacheTTL); ok {
+			if versions, err := parseVersionIndexBody(body); err == nil {
+				return versions, nil
+			}
+		}
+	}
+
+	settings, err := EffectiveSettings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load settings: %w", err)
+	}
+
+	indexURL := ActiveDistribution().IndexURL(settings)
+	fmt.Printf("Fetching Node.js versions from %s...\n", indexURL)
 
-	// Create a client with timeout
-	client := &http.Client{
-		Timeout: 30 * time.Second,
+	body, fetchErr := fetchVersionIndexBody(indexURL, settings)
+	if fetchErr != nil {
+		if cacheErr == nil {
+			if cached, ok := readIndexCache(cachePath, 0); ok {
+				fmt.Printf("Warning: %v; using cached version index from %s\n", fetchErr, cachePath)
+				return parseVersionIndexBody(cached)
+			}
+		}
+		return nil, fetchErr
+	}
+
+	versions, err := parseVersionIndexBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheErr == nil {
+		writeIndexCache(cachePath, body)
 	}
 
-	req, err := http.NewRequest("GET", "https://nodejs.org/dist/index.json", nil)
+	return versions, nil
+}
+
+// fetchVersionIndexBody performs the HTTP GET for indexURL and returns the
+// raw response body.
+func fetchVersionIndexBody(indexURL string, settings *pkg.Settings) ([]byte, error) {
+	client := NewHTTPClient(settings)
+
+	req, err := http.NewRequest("GET", indexURL, nil)
 	if err != nil {
-		fmt.Println("Error creating request:", err)
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
@@ -44,41 +132,37 @@ func FetchAvailableVersions() ([]NodeVersion, error) {
 
 	resp, err := client.Do(req)
 	if err != nil {
-		fmt.Println("Error fetching version index:", err)
 		return nil, fmt.Errorf("failed to fetch version index: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		fmt.Println("Bad status code:", resp.Status)
 		return nil, fmt.Errorf("failed to fetch version index: status code %d", resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		fmt.Println("Error reading response body:", err)
 		return nil, fmt.Errorf("failed to read version index body: %w", err)
 	}
 
-	// Try to fix common JSON issues before parsing
-	bodyStr := string(body)
-	if strings.TrimSpace(bodyStr) == "" {
-		fmt.Println("Received empty response body")
+	if strings.TrimSpace(string(body)) == "" {
 		return nil, fmt.Errorf("received empty response body")
 	}
 
-	// Try parsing the JSON
+	return body, nil
+}
+
+// parseVersionIndexBody parses a raw index.json body into sorted
+// (newest-first) NodeVersion entries, falling back to a more tolerant
+// field-by-field decode if strict unmarshaling fails.
+func parseVersionIndexBody(body []byte) ([]NodeVersion, error) {
 	var versions []NodeVersion
-	err = json.Unmarshal(body, &versions)
+	err := json.Unmarshal(body, &versions)
 	if err != nil {
-		fmt.Println("JSON parse error:", err)
-
 		// Try using a more flexible approach
 		var rawData []map[string]interface{}
 		err2 := json.Unmarshal(body, &rawData)
 		if err2 != nil {
-			fmt.Println("Even flexible parsing failed:", err2)
-
 			// Save response for debugging
 			debugFile := filepath.Join(os.TempDir(), "node_versions_response.json")
 			_ = os.WriteFile(debugFile, body, 0644)
@@ -116,9 +200,6 @@ func FetchAvailableVersions() ([]NodeVersion, error) {
 			}
 			versions[i] = version
 		}
-		fmt.Println("Manual parsing succeeded with", len(versions), "versions")
-	} else {
-		fmt.Println("Successfully parsed", len(versions), "Node.js versions")
 	}
 
 	// Sort versions (optional, but good for display)
@@ -222,47 +303,483 @@ func FetchVersionDetails(versionQuery string) (NodeVersion, error) {
 	return NodeVersion{}, fmt.Errorf("version %s not found in Node.js index", versionQuery)
 }
 
-// DownloadFile downloads a file from a URL to a local path with progress reporting
-func DownloadFile(filepath string, url string) error {
-	// Create the request
-	req, err := http.NewRequest("GET", url, nil)
+// minChunkedDownloadSize is the smallest Content-Length worth splitting
+// across multiple Range requests; below this the overhead of extra HTTP
+// round-trips outweighs any latency win.
+const minChunkedDownloadSize = 4 * 1024 * 1024 // 4MB
+
+// maxChunkRetries is how many times a single failed range request is retried
+// before the whole chunked download gives up and falls back to a plain
+// sequential stream.
+const maxChunkRetries = 3
+
+// downloadChunksOverride, when set via SetDownloadChunks (the
+// --download-chunks flag), takes precedence over the min(8, GOMAXPROCS)
+// default.
+var downloadChunksOverride int
+
+// SetDownloadChunks overrides the number of concurrent range requests used
+// for large downloads. Passing 0 or a negative value restores the default.
+func SetDownloadChunks(n int) {
+	downloadChunksOverride = n
+}
+
+// downloadChunkCount returns the configured number of concurrent range
+// requests for parallel downloads.
+func downloadChunkCount() int {
+	if downloadChunksOverride > 0 {
+		return downloadChunksOverride
+	}
+	if n := runtime.GOMAXPROCS(0); n < 8 {
+		return n
+	}
+	return 8
+}
+
+// probeRangeSupport issues a HEAD request to learn the file's size and
+// whether the server honors byte-range requests. Any failure (network error,
+// missing Content-Length, a server that doesn't answer HEAD) is treated as
+// "no range support" so the caller falls back to a plain sequential download.
+func probeRangeSupport(client *http.Client, url string) (contentLength int64, acceptsRanges bool) {
+	req, err := http.NewRequest("HEAD", url, nil)
 	if err != nil {
-		return err
+		return 0, false
 	}
+	req.Header.Set("User-Agent", "node-spark/1.0")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, false
+	}
+	defer resp.Body.Close()
 
-	// Set a user agent to avoid being blocked
+	if resp.StatusCode != http.StatusOK || resp.ContentLength <= 0 {
+		return 0, false
+	}
+
+	return resp.ContentLength, resp.Header.Get("Accept-Ranges") == "bytes"
+}
+
+// DownloadFile downloads a file from a URL to a local path with progress
+// reporting, returning the SHA-256 digest of the downloaded content as a
+// lowercase hex string.
+//
+// When the server advertises "Accept-Ranges: bytes" and the file is large
+// enough to be worth splitting, the download is fanned out across
+// downloadChunkCount() concurrent Range requests (see downloadFileChunked).
+// Otherwise, or if the chunked attempt fails outright, it falls back to a
+// single sequential stream (see downloadFileSingleStream).
+func DownloadFile(filepath string, url string) (sha256Hex string, err error) {
+	settings, err := EffectiveSettings()
+	if err != nil {
+		return "", fmt.Errorf("failed to load settings: %w", err)
+	}
+	client := NewHTTPClient(settings)
+
+	if contentLength, acceptsRanges := probeRangeSupport(client, url); acceptsRanges && contentLength >= minChunkedDownloadSize {
+		if numChunks := downloadChunkCount(); numChunks > 1 {
+			sha256Hex, err := downloadFileChunked(filepath, url, client, contentLength, numChunks)
+			if err == nil {
+				return sha256Hex, nil
+			}
+			fmt.Printf("Chunked download failed (%v), falling back to a single stream...\n", err)
+		}
+	}
+
+	return downloadFileSingleStream(filepath, url, client)
+}
+
+// downloadFileSingleStream downloads url into filepath over a single HTTP
+// stream. It streams the response through a SHA-256 hasher as it writes to
+// disk so the caller can verify integrity without a second pass over the
+// file.
+//
+// Downloads are staged in a "<filepath>.part" file. If a partial download
+// from a previous attempt is found, it is resumed with a Range request,
+// seeding the hasher with the bytes already on disk; if the server ignores
+// the Range header (200 instead of 206), the partial file is discarded and
+// the download restarts from scratch.
+func downloadFileSingleStream(filepath, url string, client *http.Client) (sha256Hex string, err error) {
+	partPath := filepath + ".part"
+	hasher := sha256.New()
+
+	var resumeFrom int64
+	if info, statErr := os.Stat(partPath); statErr == nil {
+		resumeFrom = info.Size()
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return "", err
+	}
 	req.Header.Set("User-Agent", "node-spark/1.0")
 
-	// Get the data
-	resp, err := http.DefaultClient.Do(req)
+	if resumeFrom > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", resumeFrom))
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("bad status: %s", resp.Status)
+	openFlags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Either a fresh download, or the server ignored our Range request;
+		// either way we must (re)write from the start.
+		resumeFrom = 0
+		openFlags |= os.O_TRUNC
+	case http.StatusPartialContent:
+		openFlags |= os.O_APPEND
+		if existing, readErr := os.Open(partPath); readErr == nil {
+			_, err = io.Copy(hasher, existing)
+			existing.Close()
+			if err != nil {
+				return "", fmt.Errorf("failed to seed checksum from partial download: %w", err)
+			}
+		}
+	default:
+		return "", fmt.Errorf("bad status: %s", resp.Status)
 	}
 
-	// Create the file
-	out, err := os.Create(filepath)
+	out, err := os.OpenFile(partPath, openFlags, 0644)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer out.Close()
 
+	totalLength := resp.ContentLength
+	if totalLength > 0 {
+		totalLength += resumeFrom
+	}
+
 	// Set up progress reader
 	progressReader := &ProgressReader{
 		Reader:        resp.Body,
-		ContentLength: resp.ContentLength,
+		ContentLength: totalLength,
+		TotalRead:     resumeFrom,
 		OnProgress:    PrintProgressBar,
 	}
 
-	// Write the body to file with progress reporting
-	_, err = io.Copy(out, progressReader)
+	// Write the body to file with progress reporting, hashing as we go via a
+	// TeeReader so the archive is only read from the network once.
+	_, err = io.Copy(out, io.TeeReader(progressReader, hasher))
 
 	// Print a newline after the progress bar completes
 	fmt.Println()
 
-	return err
+	if err != nil {
+		return "", err
+	}
+
+	if err := out.Close(); err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(partPath, filepath); err != nil {
+		return "", fmt.Errorf("failed to finalize downloaded file: %w", err)
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// downloadChunkRange is a half-open [Start, End) byte range assigned to one
+// worker in a chunked download.
+type downloadChunkRange struct {
+	Index      int
+	Start, End int64
+}
+
+// planDownloadChunks splits [0, contentLength) into n roughly equal,
+// non-overlapping ranges.
+func planDownloadChunks(contentLength int64, n int) []downloadChunkRange {
+	chunkSize := contentLength / int64(n)
+	ranges := make([]downloadChunkRange, 0, n)
+
+	start := int64(0)
+	for i := 0; i < n; i++ {
+		end := start + chunkSize
+		if i == n-1 {
+			end = contentLength
+		}
+		ranges = append(ranges, downloadChunkRange{Index: i, Start: start, End: end})
+		start = end
+	}
+
+	return ranges
+}
+
+// chunkProgress aggregates the byte counts reported by concurrent chunk
+// workers into a single PrintProgressBar call. Access is mutex-guarded since
+// each chunk downloads on its own goroutine.
+type chunkProgress struct {
+	mu    sync.Mutex
+	read  []int64
+	total int64
+}
+
+func (p *chunkProgress) report(index int, n int64) {
+	p.mu.Lock()
+	p.read[index] = n
+	var sum int64
+	for _, v := range p.read {
+		sum += v
+	}
+	p.mu.Unlock()
+
+	PrintProgressBar(sum, p.total)
+}
+
+// downloadChunkToFile fetches a single byte range into out at the matching
+// offset via WriteAt, retrying the whole range up to maxChunkRetries times if
+// the request or a mid-stream read fails.
+func downloadChunkToFile(client *http.Client, url string, out *os.File, rng downloadChunkRange, progress *chunkProgress) error {
+	var lastErr error
+
+	for attempt := 0; attempt < maxChunkRetries; attempt++ {
+		lastErr = func() error {
+			req, err := http.NewRequest("GET", url, nil)
+			if err != nil {
+				return err
+			}
+			req.Header.Set("User-Agent", "node-spark/1.0")
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", rng.Start, rng.End-1))
+
+			resp, err := client.Do(req)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusPartialContent {
+				return fmt.Errorf("bad status for range %d-%d: %s", rng.Start, rng.End-1, resp.Status)
+			}
+
+			var written int64
+			buf := make([]byte, 256*1024)
+			for {
+				n, readErr := resp.Body.Read(buf)
+				if n > 0 {
+					if _, werr := out.WriteAt(buf[:n], rng.Start+written); werr != nil {
+						return werr
+					}
+					written += int64(n)
+					progress.report(rng.Index, written)
+				}
+				if readErr == io.EOF {
+					break
+				}
+				if readErr != nil {
+					return readErr
+				}
+			}
+
+			if want := rng.End - rng.Start; written != want {
+				return fmt.Errorf("range %d-%d: got %d bytes, expected %d", rng.Start, rng.End-1, written, want)
+			}
+			return nil
+		}()
+
+		if lastErr == nil {
+			return nil
+		}
+		progress.report(rng.Index, 0) // retrying: don't double-count this chunk's progress
+	}
+
+	return fmt.Errorf("chunk %d failed after %d attempts: %w", rng.Index, maxChunkRetries, lastErr)
+}
+
+// downloadFileChunked downloads url into filepath using numChunks concurrent
+// Range requests, aggregating progress across all of them into one progress
+// bar. The archive is staged in a "<filepath>.part" file pre-sized to
+// contentLength, accompanied by a "<filepath>.part.chunks" sidecar recording
+// which chunks have landed (see chunkMetadata). A pre-sized .part file is
+// sparse until every chunk writes its range, so unlike the single-stream
+// download its size alone can't distinguish a finished download from an
+// interrupted one; the sidecar is what makes resuming safe. Because chunks
+// land out of order, SHA-256 can't be computed incrementally as in
+// downloadFileSingleStream either - it's computed by reading the reassembled
+// file once every chunk has landed.
+func downloadFileChunked(filepath, url string, client *http.Client, contentLength int64, numChunks int) (sha256Hex string, err error) {
+	partPath := filepath + ".part"
+	metaPath := chunkMetadataPath(partPath)
+
+	ranges := planDownloadChunks(contentLength, numChunks)
+
+	meta := loadChunkMetadata(metaPath, contentLength, numChunks)
+	if meta == nil || !partFileExists(partPath, contentLength) {
+		if err := stageChunkedPartFile(partPath, contentLength); err != nil {
+			return "", err
+		}
+		meta = &chunkMetadata{ContentLength: contentLength, NumChunks: numChunks, Completed: make([]bool, numChunks)}
+	} else if meta.completedCount() > 0 {
+		fmt.Printf("Resuming chunked download: %d/%d chunks already complete...\n", meta.completedCount(), numChunks)
+	}
+
+	out, err := os.OpenFile(partPath, os.O_WRONLY, 0644)
+	if err != nil {
+		return "", err
+	}
+
+	progress := &chunkProgress{read: make([]int64, len(ranges)), total: contentLength}
+	for _, rng := range ranges {
+		if meta.Completed[rng.Index] {
+			progress.read[rng.Index] = rng.End - rng.Start
+		}
+	}
+
+	var metaMu sync.Mutex
+	sem := make(chan struct{}, numChunks)
+	errCh := make(chan error, len(ranges))
+	var wg sync.WaitGroup
+
+	for _, rng := range ranges {
+		if meta.Completed[rng.Index] {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(rng downloadChunkRange) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			chunkErr := downloadChunkToFile(client, url, out, rng, progress)
+			if chunkErr == nil {
+				metaMu.Lock()
+				meta.Completed[rng.Index] = true
+				saveErr := meta.save(metaPath) // best-effort: a failed save just costs a re-download on crash
+				metaMu.Unlock()
+				if saveErr != nil {
+					fmt.Printf("Warning: failed to persist chunk progress: %v\n", saveErr)
+				}
+			}
+			errCh <- chunkErr
+		}(rng)
+	}
+
+	wg.Wait()
+	close(errCh)
+	fmt.Println()
+
+	closeErr := out.Close()
+	for chunkErr := range errCh {
+		if chunkErr != nil {
+			return "", chunkErr
+		}
+	}
+	if closeErr != nil {
+		return "", closeErr
+	}
+
+	sha256Hex, err = hashFile(partPath)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(partPath, filepath); err != nil {
+		return "", fmt.Errorf("failed to finalize downloaded file: %w", err)
+	}
+	os.Remove(metaPath) // best-effort: a leftover sidecar just gets ignored by the contentLength/numChunks check next time
+
+	return sha256Hex, nil
+}
+
+// stageChunkedPartFile (re)creates partPath as a sparse file of exactly
+// contentLength bytes so concurrent chunk workers can WriteAt their own
+// offsets independently.
+func stageChunkedPartFile(partPath string, contentLength int64) error {
+	out, err := os.OpenFile(partPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	return out.Truncate(contentLength)
+}
+
+// partFileExists reports whether partPath looks like the pre-sized file
+// stageChunkedPartFile would have created for contentLength - a sanity check
+// that the .chunks sidecar and the .part file it describes are still in
+// sync before trusting the sidecar's completed-chunk bookkeeping.
+func partFileExists(partPath string, contentLength int64) bool {
+	info, err := os.Stat(partPath)
+	return err == nil && info.Size() == contentLength
+}
+
+// chunkMetadataPath returns the sidecar file path that tracks which of
+// partPath's chunks have been fully written, so an interrupted chunked
+// download can resume by skipping only the chunks already on disk rather
+// than inferring completeness from partPath's size (which is pre-sized to
+// its final length from the start and so is never a useful completeness
+// signal on its own).
+func chunkMetadataPath(partPath string) string {
+	return partPath + ".chunks"
+}
+
+// chunkMetadata is the on-disk (JSON) record of chunk completion for one
+// in-progress chunked download.
+type chunkMetadata struct {
+	ContentLength int64  `json:"contentLength"`
+	NumChunks     int    `json:"numChunks"`
+	Completed     []bool `json:"completed"`
+}
+
+// completedCount returns how many of m's chunks are marked done.
+func (m *chunkMetadata) completedCount() int {
+	n := 0
+	for _, done := range m.Completed {
+		if done {
+			n++
+		}
+	}
+	return n
+}
+
+// save writes m to path as JSON, overwriting any previous contents.
+func (m *chunkMetadata) save(path string) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// loadChunkMetadata reads and validates the sidecar at path, returning nil if
+// it doesn't exist, is corrupt, or describes a different download (a
+// different contentLength or numChunks than the one now requested, e.g. the
+// server's resource changed or --download-chunks was tuned differently) -
+// any of which make its completed-chunk bookkeeping untrustworthy, so the
+// caller falls back to staging a fresh .part file instead of resuming.
+func loadChunkMetadata(path string, contentLength int64, numChunks int) *chunkMetadata {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var m chunkMetadata
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil
+	}
+	if m.ContentLength != contentLength || m.NumChunks != numChunks || len(m.Completed) != numChunks {
+		return nil
+	}
+
+	return &m
+}
+
+// hashFile returns the lowercase hex SHA-256 digest of path's contents.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
 }
@@ -0,0 +1,89 @@
+// internal/httpclient.go
+
+package internal
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/s42yt/node-spark/pkg"
+)
+
+// EffectiveSettings loads the persisted settings file and applies environment
+// variable overrides on top, so corporate-proxy or mirror users (e.g. behind
+// the Taobao mirror in China) don't have to edit settings.json by hand.
+// NODE_SPARK_NODE_MIRROR and NODE_MIRROR are both accepted for the Node
+// distribution mirror, the latter matching the env var name other Node
+// version managers already use; NODE_SPARK_MIRROR is kept as a deprecated
+// alias for the same setting. NODE_SPARK_NODE_MIRROR takes precedence when
+// more than one is set.
+func EffectiveSettings() (*pkg.Settings, error) {
+	settings, err := pkg.LoadSettings(pkg.GetSettingsPath())
+	if err != nil {
+		return nil, err
+	}
+
+	if mirror := os.Getenv("NODE_SPARK_MIRROR"); mirror != "" {
+		settings.NodeMirror = mirror
+	}
+	if mirror := os.Getenv("NODE_MIRROR"); mirror != "" {
+		settings.NodeMirror = mirror
+	}
+	if mirror := os.Getenv("NODE_SPARK_NODE_MIRROR"); mirror != "" {
+		settings.NodeMirror = mirror
+	}
+	if mirror := os.Getenv("NODE_SPARK_NPM_MIRROR"); mirror != "" {
+		settings.NpmMirror = mirror
+	}
+	if proxy := os.Getenv("NODE_SPARK_PROXY"); proxy != "" {
+		settings.Proxy = proxy
+	}
+
+	return settings, nil
+}
+
+// NewHTTPClient builds an *http.Client honoring the configured proxy (falling
+// back to HTTP_PROXY/HTTPS_PROXY when unset) and verify_ssl setting.
+func NewHTTPClient(settings *pkg.Settings) *http.Client {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: !settings.VerifySSL,
+		},
+	}
+
+	if settings.Proxy != "" {
+		if proxyURL, err := url.Parse(settings.Proxy); err == nil {
+			transport.Proxy = http.ProxyURL(proxyURL)
+		}
+	}
+
+	return &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: transport,
+	}
+}
+
+// NodeDistBaseURL returns the configured Node.js distribution mirror with no
+// trailing slash, defaulting to the official CDN.
+func NodeDistBaseURL(settings *pkg.Settings) string {
+	base := settings.NodeMirror
+	if base == "" {
+		base = "https://nodejs.org/dist"
+	}
+	return strings.TrimSuffix(base, "/")
+}
+
+// NpmRegistryBaseURL returns the configured npm registry mirror with no
+// trailing slash, defaulting to the official registry.
+func NpmRegistryBaseURL(settings *pkg.Settings) string {
+	base := settings.NpmMirror
+	if base == "" {
+		base = "https://registry.npmjs.org"
+	}
+	return strings.TrimSuffix(base, "/")
+}
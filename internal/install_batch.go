@@ -0,0 +1,155 @@
+// internal/install_batch.go
+
+package internal
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/s42yt/node-spark/pkg"
+)
+
+// InstallVersions installs multiple Node.js versions concurrently, bounded
+// by concurrency simultaneous downloads (concurrency <= 0 defaults to
+// runtime.NumCPU()), via an errgroup.Group + semaphore the way ficsit-cli
+// bounds its parallel mod downloads. Each version is installed through the
+// ordinary InstallNodeVersion, so it downloads into its own
+// version/arch-specific temp filename (see archiveTempPath in
+// install_node.go) - concurrent installs never collide on the same archive
+// path - and every worker's config.InstalledVersions/InstalledArches access,
+// reads included (installedArches) as well as writes (recordInstalledArch),
+// is serialized through configMu (arch.go), so the config this function
+// leaves behind is safe for the caller to save once it returns. Progress
+// across every in-flight download is rendered together by batchProgress.
+// offerActivate is always false for installs driven through here: prompting
+// once per worker on a shared stdin makes no sense for a batch.
+func InstallVersions(versions []string, config *pkg.Config, concurrency int) error {
+	if len(versions) == 0 {
+		return fmt.Errorf("no versions given")
+	}
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+
+	progress := newBatchProgress(versions)
+	defer progress.stop()
+
+	g := new(errgroup.Group)
+	g.SetLimit(concurrency)
+
+	results := make([]error, len(versions))
+	for i, version := range versions {
+		i, version := i, version
+		g.Go(func() error {
+			results[i] = installTrackedVersion(version, config, progress)
+			return nil // collected per-version below; one failure shouldn't cancel the rest
+		})
+	}
+	_ = g.Wait()
+
+	progress.stop()
+
+	var failed []string
+	for i, err := range results {
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", versions[i], err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to install %d of %d version(s):\n  %s", len(failed), len(versions), strings.Join(failed, "\n  "))
+	}
+
+	return nil
+}
+
+// installTrackedVersion installs version, registering its expected archive
+// path with progress first so the dashboard has something to poll for as
+// soon as the download starts.
+func installTrackedVersion(version string, config *pkg.Config, progress *batchProgress) error {
+	if nodeArch, nodeOS, ext, err := detectSystemInfo(""); err == nil {
+		versionStr := version
+		if !strings.HasPrefix(versionStr, "v") {
+			versionStr = "v" + version
+		}
+		progress.trackPath(version, archiveTempPath(versionStr, nodeOS, nodeArch, ext)+".part")
+	}
+
+	_, err := InstallNodeVersion(version, config, false, "", false)
+	return err
+}
+
+// batchProgress renders a small multi-line dashboard - one row per version,
+// showing bytes downloaded and download speed - by periodically stat-ing
+// each version's in-progress ".part" file. It's intentionally simple
+// (append-only lines rather than redrawing in place) to match the rest of
+// node-spark's plain-text progress output (see PrintIndeterminateProgress,
+// PrintProgressBar in install_global.go/utils.go).
+type batchProgress struct {
+	mu       sync.Mutex
+	versions []string
+	paths    map[string]string
+	lastSize map[string]int64
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func newBatchProgress(versions []string) *batchProgress {
+	bp := &batchProgress{
+		versions: versions,
+		paths:    make(map[string]string),
+		lastSize: make(map[string]int64),
+		done:     make(chan struct{}),
+	}
+	go bp.render()
+	return bp
+}
+
+func (bp *batchProgress) trackPath(version, partPath string) {
+	bp.mu.Lock()
+	bp.paths[version] = partPath
+	bp.mu.Unlock()
+}
+
+func (bp *batchProgress) stop() {
+	bp.stopOnce.Do(func() { close(bp.done) })
+}
+
+func (bp *batchProgress) render() {
+	const interval = 2 * time.Second
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-bp.done:
+			return
+		case <-ticker.C:
+			bp.printFrame(interval)
+		}
+	}
+}
+
+func (bp *batchProgress) printFrame(interval time.Duration) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	fmt.Printf("--- Install progress (%d version(s)) ---\n", len(bp.versions))
+	for _, version := range bp.versions {
+		path, tracked := bp.paths[version]
+		var size int64
+		if tracked {
+			if info, err := os.Stat(path); err == nil {
+				size = info.Size()
+			}
+		}
+		speed := float64(size-bp.lastSize[version]) / interval.Seconds()
+		bp.lastSize[version] = size
+		fmt.Printf("  %-20s %8.1f MB  (%.1f MB/s)\n", version, float64(size)/1024/1024, speed/1024/1024)
+	}
+}
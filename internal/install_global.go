@@ -11,6 +11,140 @@ import (
 	"time"
 )
 
+// nodeSparkPathDirs returns the directories that need to be on PATH for a
+// global install: just the shared shim directory (see shimDir in
+// shims.go). node, npm, npx, and nsk itself all get a shim there, so
+// switching versions only ever rewrites the node/npm/npx shims in place -
+// PATH never needs touching again after the one edit done here, in
+// InstallGlobal/InstallGlobalSilently.
+func nodeSparkPathDirs() ([]string, error) {
+	dir, err := shimDir()
+	if err != nil {
+		return nil, err
+	}
+	return []string{dir}, nil
+}
+
+// addUnixProfilePaths appends a single "# Added by node-spark" export block
+// adding dirs to PATH to every shell profile that exists. It's idempotent:
+// a profile that already has the marker is left untouched, so re-running
+// InstallGlobal (or installing before the first `nsk use`) never duplicates
+// entries.
+func addUnixProfilePaths(dirs []string) error {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+
+	var block strings.Builder
+	block.WriteString("\n# Added by node-spark\n")
+	for _, dir := range dirs {
+		fmt.Fprintf(&block, "export PATH=\"%s:$PATH\"\n", dir)
+	}
+
+	profiles := []string{
+		filepath.Join(homeDir, ".bashrc"),
+		filepath.Join(homeDir, ".bash_profile"),
+		filepath.Join(homeDir, ".zshrc"),
+		filepath.Join(homeDir, ".profile"),
+	}
+
+	for _, profile := range profiles {
+		content, err := os.ReadFile(profile)
+		if err != nil {
+			continue // profile doesn't exist
+		}
+		if strings.Contains(string(content), "# Added by node-spark") {
+			continue // already set up
+		}
+		if err := os.WriteFile(profile, append(content, []byte(block.String())...), 0644); err != nil {
+			return fmt.Errorf("failed to update %s: %w", profile, err)
+		}
+	}
+
+	return nil
+}
+
+// removeUnixProfilePaths strips the "# Added by node-spark" block (and any
+// single-line PATH exports it replaced, from installs predating that block)
+// from every shell profile, undoing addUnixProfilePaths for UninstallGlobal.
+func removeUnixProfilePaths() error {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+
+	profiles := []string{
+		filepath.Join(homeDir, ".bashrc"),
+		filepath.Join(homeDir, ".bash_profile"),
+		filepath.Join(homeDir, ".zshrc"),
+		filepath.Join(homeDir, ".profile"),
+	}
+
+	for _, profile := range profiles {
+		content, err := os.ReadFile(profile)
+		if err != nil {
+			continue
+		}
+
+		lines := strings.Split(string(content), "\n")
+		var kept []string
+		inBlock := false
+		for _, line := range lines {
+			if strings.Contains(line, "# Added by node-spark") {
+				inBlock = true
+				continue
+			}
+			if inBlock {
+				if strings.HasPrefix(strings.TrimSpace(line), "export PATH=") {
+					continue
+				}
+				inBlock = false
+			}
+			if strings.Contains(line, "node-spark") && strings.HasPrefix(strings.TrimSpace(line), "export PATH=") {
+				continue // legacy single-line entry from an older install
+			}
+			kept = append(kept, line)
+		}
+
+		if err := os.WriteFile(profile, []byte(strings.Join(kept, "\n")), 0644); err != nil {
+			return fmt.Errorf("failed to update %s: %w", profile, err)
+		}
+	}
+
+	return nil
+}
+
+// updateWindowsUserPath adds every directory in dirs to the user's
+// persistent PATH (via PowerShell, for proper escaping) that isn't already
+// there, shared by installWindowsGlobal and installWindowsGlobalSilently so
+// the shim directory (see nodeSparkPathDirs) only ever needs adding once.
+func updateWindowsUserPath(dirs []string) error {
+	quoted := make([]string, len(dirs))
+	for i, dir := range dirs {
+		quoted[i] = fmt.Sprintf("'%s'", dir)
+	}
+
+	pathCmd := fmt.Sprintf(`
+		$dirs = @(%s)
+		$currentPath = [Environment]::GetEnvironmentVariable('Path', 'User')
+		foreach ($dir in $dirs) {
+			if ($currentPath -notlike "*$dir*") {
+				$currentPath = "$currentPath;$dir"
+				Write-Output "Added to PATH: $dir"
+			} else {
+				Write-Output "Path already contains: $dir"
+			}
+		}
+		[Environment]::SetEnvironmentVariable('Path', $currentPath, 'User')
+	`, strings.Join(quoted, ", "))
+
+	cmd := exec.Command("powershell", "-Command", pathCmd)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
 // IsInstalledGlobally checks if node-spark is already installed globally
 func IsInstalledGlobally() bool {
 	switch runtime.GOOS {
@@ -93,27 +227,24 @@ func installWindowsGlobalSilently() error {
 		return fmt.Errorf("failed to copy executable: %w", err)
 	}
 
-	// Update PATH with PowerShell to ensure proper escaping and handling
-	pathCmd := fmt.Sprintf(`
-		$destDir = '%s'
-		$currentPath = [Environment]::GetEnvironmentVariable('Path', 'User')
-		if ($currentPath -notlike "*$destDir*") {
-			[Environment]::SetEnvironmentVariable('Path', "$currentPath;$destDir", 'User')
-			Write-Host "Added to PATH: $destDir"
-		} else {
-			Write-Host "Path already contains: $destDir"
-		}
-	`, destDir)
+	dir, err := shimDir()
+	if err != nil {
+		return fmt.Errorf("failed to determine shim directory: %w", err)
+	}
+	if err := writeNskShim(dir, destPath); err != nil {
+		return fmt.Errorf("failed to install the nsk shim: %w", err)
+	}
 
-	cmd := exec.Command("powershell", "-Command", pathCmd)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
+	pathDirs, err := nodeSparkPathDirs()
+	if err != nil {
+		return fmt.Errorf("failed to determine PATH directories: %w", err)
+	}
+	if err := updateWindowsUserPath(pathDirs); err != nil {
 		return fmt.Errorf("failed to update PATH: %w", err)
 	}
 
 	// Also update the current process PATH so it's available immediately
-	os.Setenv("PATH", os.Getenv("PATH")+";"+destDir)
+	os.Setenv("PATH", os.Getenv("PATH")+";"+strings.Join(pathDirs, ";"))
 
 	return nil
 }
@@ -179,33 +310,25 @@ func installUnixGlobalSilently() error {
 		return err
 	}
 
-	path := os.Getenv("PATH")
-	if !strings.Contains(path, destDir) {
-		profiles := []string{
-			filepath.Join(homeDir, ".bashrc"),
-			filepath.Join(homeDir, ".bash_profile"),
-			filepath.Join(homeDir, ".zshrc"),
-			filepath.Join(homeDir, ".profile"),
-		}
-
-		for _, profile := range profiles {
-			if _, err := os.Stat(profile); err == nil {
-				appendCmd := fmt.Sprintf("\n# Added by node-spark\nexport PATH=\"%s:$PATH\"\n", destDir)
-				profileContent, err := os.ReadFile(profile)
-				if err == nil && !strings.Contains(string(profileContent), destDir) {
-					os.WriteFile(profile, append(profileContent, []byte(appendCmd)...), 0644)
-				}
-			}
-		}
+	dir, err := shimDir()
+	if err != nil {
+		return fmt.Errorf("failed to determine shim directory: %w", err)
+	}
+	if err := writeNskShim(dir, destPath); err != nil {
+		return fmt.Errorf("failed to install the nsk shim: %w", err)
+	}
 
-		os.Setenv("PATH", destDir+":"+os.Getenv("PATH"))
+	path := os.Getenv("PATH")
+	if !strings.Contains(path, dir) {
+		os.Setenv("PATH", dir+":"+os.Getenv("PATH"))
 	}
 
-	if destDir != "/usr/local/bin" {
-		lnCmd := exec.Command("sudo", "ln", "-sf", destPath, "/usr/local/bin/nsk")
-		if err := lnCmd.Run(); err != nil {
-			exec.Command("ln", "-sf", destPath, "/usr/local/bin/nsk").Run()
-		}
+	pathDirs, err := nodeSparkPathDirs()
+	if err != nil {
+		return fmt.Errorf("failed to determine PATH directories: %w", err)
+	}
+	if err := addUnixProfilePaths(pathDirs); err != nil {
+		return fmt.Errorf("failed to update shell profiles: %w", err)
 	}
 
 	return nil
@@ -280,25 +403,22 @@ func installWindowsGlobal() error {
 		return fmt.Errorf("failed to install executable: %w", err)
 	}
 
-	// Update PATH with PowerShell for better handling
-	pathCmd := fmt.Sprintf(`
-		$destDir = '%s'
-		$currentPath = [Environment]::GetEnvironmentVariable('Path', 'User')
-		if ($currentPath -notlike "*$destDir*") {
-			[Environment]::SetEnvironmentVariable('Path', "$currentPath;$destDir", 'User')
-			Write-Output "Added to PATH: $destDir"
-		} else {
-			Write-Output "Path already contains: $destDir"
-		}
-	`, destDir)
+	dir, err := shimDir()
+	if err != nil {
+		return fmt.Errorf("failed to determine shim directory: %w", err)
+	}
+	if err := writeNskShim(dir, destPath); err != nil {
+		return fmt.Errorf("failed to install the nsk shim: %w", err)
+	}
 
-	cmd := exec.Command("powershell", "-Command", pathCmd)
-	output, err := cmd.CombinedOutput()
+	pathDirs, err := nodeSparkPathDirs()
 	if err != nil {
-		return fmt.Errorf("failed to update PATH: %w (output: %s)", err, string(output))
+		return fmt.Errorf("failed to determine PATH directories: %w", err)
+	}
+	if err := updateWindowsUserPath(pathDirs); err != nil {
+		return fmt.Errorf("failed to update PATH: %w", err)
 	}
 
-	fmt.Println(string(output))
 	fmt.Println("Global installation complete. You may need to restart your terminal or computer for the PATH changes to take effect.")
 	return nil
 }
@@ -352,34 +472,25 @@ func installUnixGlobal() error {
 		return fmt.Errorf("failed to set executable permissions: %w", err)
 	}
 
-	path := os.Getenv("PATH")
-	if !strings.Contains(path, destDir) {
-		os.Setenv("PATH", destDir+":"+os.Getenv("PATH"))
-
-		homeDir, _ := os.UserHomeDir()
-		profiles := []string{
-			filepath.Join(homeDir, ".bashrc"),
-			filepath.Join(homeDir, ".bash_profile"),
-			filepath.Join(homeDir, ".zshrc"),
-			filepath.Join(homeDir, ".profile"),
-		}
+	dir, err := shimDir()
+	if err != nil {
+		return fmt.Errorf("failed to determine shim directory: %w", err)
+	}
+	if err := writeNskShim(dir, destPath); err != nil {
+		return fmt.Errorf("failed to install the nsk shim: %w", err)
+	}
 
-		for _, profile := range profiles {
-			if _, err := os.Stat(profile); err == nil {
-				appendCmd := fmt.Sprintf("\n# Added by node-spark\nexport PATH=\"%s:$PATH\"\n", destDir)
-				profileContent, err := os.ReadFile(profile)
-				if err == nil && !strings.Contains(string(profileContent), destDir) {
-					os.WriteFile(profile, append(profileContent, []byte(appendCmd)...), 0644)
-				}
-			}
-		}
+	path := os.Getenv("PATH")
+	if !strings.Contains(path, dir) {
+		os.Setenv("PATH", dir+":"+os.Getenv("PATH"))
 	}
 
-	if destDir != "/usr/local/bin" {
-		lnCmd := exec.Command("sudo", "ln", "-sf", destPath, "/usr/local/bin/nsk")
-		if err := lnCmd.Run(); err != nil {
-			exec.Command("ln", "-sf", destPath, "/usr/local/bin/nsk").Run()
-		}
+	pathDirs, err := nodeSparkPathDirs()
+	if err != nil {
+		return fmt.Errorf("failed to determine PATH directories: %w", err)
+	}
+	if err := addUnixProfilePaths(pathDirs); err != nil {
+		return fmt.Errorf("failed to update shell profiles: %w", err)
 	}
 
 	return nil
@@ -459,8 +570,13 @@ func uninstallWindowsGlobal() error {
 		return fmt.Errorf("failed to remove installation directory: %w", err)
 	}
 
+	dir, err := shimDir()
+	if err == nil {
+		os.RemoveAll(dir)
+	}
+
 	cmd := exec.Command("powershell", "-Command",
-		fmt.Sprintf(`[Environment]::SetEnvironmentVariable("PATH", ($env:PATH -replace [regex]::Escape(";%s"), ""), [EnvironmentVariableTarget]::User)`, destDir))
+		fmt.Sprintf(`$p = $env:PATH -replace [regex]::Escape(";%s"), ""; [Environment]::SetEnvironmentVariable("PATH", $p, [EnvironmentVariableTarget]::User)`, dir))
 	_ = cmd.Run()
 
 	return nil
@@ -495,33 +611,12 @@ func uninstallUnixGlobal() error {
 		return fmt.Errorf("node-spark is not installed globally or couldn't be found")
 	}
 
-	profiles := []string{
-		filepath.Join(homeDir, ".bashrc"),
-		filepath.Join(homeDir, ".bash_profile"),
-		filepath.Join(homeDir, ".zshrc"),
-		filepath.Join(homeDir, ".profile"),
+	if dir, err := shimDir(); err == nil {
+		os.RemoveAll(dir)
 	}
 
-	for _, profile := range profiles {
-		if _, err := os.Stat(profile); err == nil {
-			content, err := os.ReadFile(profile)
-			if err == nil {
-				lines := strings.Split(string(content), "\n")
-				var newLines []string
-
-				for _, line := range lines {
-					if strings.Contains(line, "# Added by node-spark") ||
-						(strings.Contains(line, "export PATH=") &&
-							(strings.Contains(line, "/bin/nsk") ||
-								strings.Contains(line, "node-spark"))) {
-						continue
-					}
-					newLines = append(newLines, line)
-				}
-
-				os.WriteFile(profile, []byte(strings.Join(newLines, "\n")), 0644)
-			}
-		}
+	if err := removeUnixProfilePaths(); err != nil {
+		fmt.Printf("Warning: failed to clean up shell profiles: %v\n", err)
 	}
 
 	return nil
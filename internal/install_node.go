@@ -2,6 +2,7 @@ package internal
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -13,78 +14,139 @@ import (
 
 // InstallVersion is the main entry point for node installation
 func InstallVersion(version string, config *pkg.Config) error {
-	return InstallNodeVersion(version, config)
+	_, err := InstallNodeVersion(version, config, false, "", true)
+	return err
 }
 
-// InstallNodeVersion properly installs a Node.js version
-func InstallNodeVersion(version string, config *pkg.Config) error {
+// archiveTempPath returns the temp-directory path InstallNodeVersion
+// downloads a version's archive to. It's exported within the package so
+// InstallVersions' progress dashboard (install_batch.go) can watch the same
+// "<path>.part" file mid-download without guessing the naming scheme.
+func archiveTempPath(versionStr, nodeOS, nodeArch, ext string) string {
+	filename := fmt.Sprintf("node-%s-%s-%s.%s", versionStr, nodeOS, nodeArch, ext)
+	return filepath.Join(os.TempDir(), filename)
+}
+
+// InstallNodeVersion properly installs a Node.js version. When noVerify is
+// true, archive verification is skipped entirely regardless of
+// config.VerificationPolicy (equivalent to pkg.VerifyOff for this one
+// install). Otherwise the archive is checked per config.VerificationPolicy,
+// defaulting to pkg.VerifyChecksum when unset. archOverride selects a
+// non-default architecture to install (e.g. "arm64" on an x64 host, to run
+// under Rosetta, or vice versa); an empty archOverride installs for the
+// architecture node-spark itself is running as. Multiple architectures of
+// the same version coexist side-by-side under
+// installPath/<version>/<arch> (see internal/arch.go). When offerActivate is
+// false, the "no active version yet, activate this one?" prompt at the end
+// is skipped - InstallVersions passes false so a concurrent batch install
+// never blocks on stdin. It returns a short summary of what verification was
+// performed, for callers (the CLI and the TUI) to surface to the user.
+func InstallNodeVersion(version string, config *pkg.Config, noVerify bool, archOverride string, offerActivate bool) (verification string, err error) {
 	fmt.Printf("Installing Node.js version %s...\n", version)
 
-	// First, check if this version is already installed
 	cleanVersion := strings.TrimPrefix(version, "v")
-	for _, v := range config.InstalledVersions {
-		if v == cleanVersion {
-			fmt.Printf("Node.js version %s is already installed. Use 'nsk use %s' to switch to it.\n", cleanVersion, cleanVersion)
-			return nil
-		}
+	if err := migrateFlatVersionDir(config, cleanVersion); err != nil {
+		return "", err
+	}
+
+	// Determine download URL and filename with improved architecture detection
+	nodeArch, nodeOS, ext, err := detectSystemInfo(archOverride)
+	if err != nil {
+		return "", err
+	}
+
+	// First, check if this version+arch is already installed
+	if stringSliceContains(installedArches(config, cleanVersion), nodeArch) {
+		fmt.Printf("Node.js version %s (%s) is already installed. Use 'nsk use %s %s' to switch to it.\n", cleanVersion, nodeArch, cleanVersion, nodeArch)
+		return "", nil
 	}
 
 	installPath := pkg.GetInstallPath(config)
-	versionDir := filepath.Join(installPath, version)
+	versionDir := filepath.Join(installPath, cleanVersion, nodeArch)
 
 	// 1. Ensure the base installation directory exists
 	if err := os.MkdirAll(installPath, 0755); err != nil {
-		return fmt.Errorf("failed to create installation directory %s: %w", installPath, err)
+		return "", fmt.Errorf("failed to create installation directory %s: %w", installPath, err)
 	}
 
-	// 2. Ensure the specific version directory exists
+	// 2. Ensure the specific version/arch directory exists
 	if err := os.MkdirAll(versionDir, 0755); err != nil {
-		return fmt.Errorf("failed to create version directory %s: %w", versionDir, err)
+		return "", fmt.Errorf("failed to create version directory %s: %w", versionDir, err)
 	}
 
-	// 3. Determine download URL and filename with improved architecture detection
-	// First, check if we need to add v prefix
+	// 3. First, check if we need to add v prefix
 	versionStr := version
 	if !strings.HasPrefix(versionStr, "v") {
 		versionStr = "v" + version
 	}
 
-	// Improved architecture detection
-	nodeArch, nodeOS, ext, err := detectSystemInfo()
+	settings, err := EffectiveSettings()
 	if err != nil {
-		return err
+		return "", fmt.Errorf("failed to load settings: %w", err)
 	}
 
+	dist := ActiveDistribution()
 	filename := fmt.Sprintf("node-%s-%s-%s.%s", versionStr, nodeOS, nodeArch, ext)
-	downloadURL := fmt.Sprintf("https://nodejs.org/dist/%s/%s", versionStr, filename)
+	downloadURL := dist.ArchiveURL(settings, versionStr, nodeOS, nodeArch, ext)
 
 	// 4. Download the Node.js archive
-	archivePath := filepath.Join(os.TempDir(), filename) // Download to temp dir first
+	archivePath := archiveTempPath(versionStr, nodeOS, nodeArch, ext) // Download to temp dir first
 	fmt.Printf("Downloading %s from %s...\n", filename, downloadURL)
-	err = DownloadFile(archivePath, downloadURL)
+	digest, err := DownloadFile(archivePath, downloadURL)
 	if err != nil {
-		// If download fails, try alternative architectures (Windows may need x86 instead of x64)
-		if runtime.GOOS == "windows" && nodeArch == "x64" {
-			fmt.Println("x64 download failed, trying x86 version instead...")
-			nodeArch = "x86"
-			filename = fmt.Sprintf("node-%s-%s-%s.%s", versionStr, nodeOS, nodeArch, ext)
-			downloadURL = fmt.Sprintf("https://nodejs.org/dist/%s/%s", versionStr, filename)
-			archivePath = filepath.Join(os.TempDir(), filename)
-			err = DownloadFile(archivePath, downloadURL)
+		// If download fails, try the next architecture in the fallback chain
+		// (Windows ARM64 falling back to x64, or either falling back to x86).
+		// Only when the caller didn't pin an arch explicitly - we shouldn't
+		// silently substitute one --arch for another.
+		if archOverride == "" && runtime.GOOS == "windows" {
+			for _, fallbackArch := range archFallbackChain(nodeArch) {
+				candidateURL := dist.ArchiveURL(settings, versionStr, nodeOS, fallbackArch, ext)
+				if !probeArchiveExists(candidateURL) {
+					continue
+				}
+				fmt.Printf("%s download failed, trying %s version instead...\n", nodeArch, fallbackArch)
+				nodeArch = fallbackArch
+				versionDir = filepath.Join(installPath, cleanVersion, nodeArch)
+				if mkErr := os.MkdirAll(versionDir, 0755); mkErr != nil {
+					return "", fmt.Errorf("failed to create version directory %s: %w", versionDir, mkErr)
+				}
+				filename = fmt.Sprintf("node-%s-%s-%s.%s", versionStr, nodeOS, nodeArch, ext)
+				downloadURL = candidateURL
+				archivePath = archiveTempPath(versionStr, nodeOS, nodeArch, ext)
+				digest, err = DownloadFile(archivePath, downloadURL)
+				if err == nil {
+					break
+				}
+			}
 		}
 
 		if err != nil {
-			return fmt.Errorf("failed to download Node.js archive: %w", err)
+			return "", fmt.Errorf("failed to download Node.js archive: %w", err)
 		}
 	}
 	defer os.Remove(archivePath) // Clean up downloaded archive
 	fmt.Println("Download complete.")
 
+	// 4b. Verify the archive against the release's SHASUMS256.txt (and, under
+	// VerifyStrict, its OpenPGP signature) per config.VerificationPolicy.
+	// --no-verify overrides the configured policy for this one install.
+	policy := config.VerificationPolicy
+	if noVerify {
+		policy = pkg.VerifyOff
+	}
+	fmt.Println("Verifying archive...")
+	verification, err = VerifyArchive(policy, versionStr, filename, digest)
+	if err != nil {
+		os.Remove(archivePath)
+		return "", fmt.Errorf("archive verification failed: %w", err)
+	}
+	fmt.Printf("Archive verification: %s.\n", verification)
+
 	// 5. Extract the downloaded archive
 	fmt.Printf("Extracting %s to %s...\n", filename, versionDir)
 	err = ExtractArchive(archivePath, versionDir)
 	if err != nil {
-		return fmt.Errorf("failed to extract Node.js archive: %w", err)
+		return "", fmt.Errorf("failed to extract Node.js archive: %w", err)
 	}
 	fmt.Println("Extraction complete.")
 
@@ -96,7 +158,7 @@ func InstallNodeVersion(version string, config *pkg.Config) error {
 			// Look in the bin subdirectory
 			nodePath = filepath.Join(versionDir, "bin", "node.exe")
 			if _, err := os.Stat(nodePath); os.IsNotExist(err) {
-				return fmt.Errorf("node executable not found after extraction")
+				return "", fmt.Errorf("node executable not found after extraction")
 			}
 		}
 
@@ -104,48 +166,42 @@ func InstallNodeVersion(version string, config *pkg.Config) error {
 		cmd := exec.Command(nodePath, "--version")
 		output, err := cmd.CombinedOutput()
 		if err != nil {
-			return fmt.Errorf("installed node binary is invalid: %w\nOutput: %s", err, string(output))
+			return "", fmt.Errorf("installed node binary is invalid: %w\nOutput: %s", err, string(output))
 		}
 		fmt.Printf("Verified installation with output: %s\n", strings.TrimSpace(string(output)))
 	}
 
 	// 7. Update config
-	found := false
-	for _, v := range config.InstalledVersions {
-		if v == cleanVersion {
-			found = true
-			break
-		}
-	}
-	if !found {
-		config.InstalledVersions = append(config.InstalledVersions, cleanVersion)
-	}
+	recordInstalledArch(config, cleanVersion, nodeArch)
 
-	fmt.Printf("Successfully installed Node.js version %s.\n", version)
+	fmt.Printf("Successfully installed Node.js version %s (%s).\n", version, nodeArch)
 
 	// Offer to use this version if no version is currently active
-	if config.ActiveVersion == "" {
+	if offerActivate && config.ActiveVersion == "" {
 		fmt.Println("No Node.js version is currently active.")
-		fmt.Printf("Would you like to use Node.js %s now? [Y/n] ", version)
+		fmt.Printf("Would you like to use Node.js %s (%s) now? [Y/n] ", version, nodeArch)
 
 		var response string
 		fmt.Scanln(&response)
 		response = strings.ToLower(response)
 
 		if response == "" || response == "y" || response == "yes" {
-			if err := SetActiveVersion(version, config); err != nil {
+			if err := SetActiveVersion(version, nodeArch, config, true); err != nil {
 				fmt.Printf("Warning: Failed to set active version: %v\n", err)
 			} else {
-				fmt.Printf("Node.js %s is now active.\n", version)
+				fmt.Printf("Node.js %s (%s) is now active.\n", version, nodeArch)
 			}
 		}
 	}
 
-	return nil
+	return verification, nil
 }
 
-// detectSystemInfo determines the system information needed for Node.js installation
-func detectSystemInfo() (nodeArch, nodeOS, ext string, err error) {
+// detectSystemInfo determines the system information needed for Node.js
+// installation. archOverride, when non-empty, pins the architecture (e.g.
+// "arm64" on an x64 host) instead of detecting it, bypassing the Windows
+// probing below entirely.
+func detectSystemInfo(archOverride string) (nodeArch, nodeOS, ext string, err error) {
 	osName := runtime.GOOS
 	ext = "tar.gz" // Default extension
 
@@ -162,50 +218,32 @@ func detectSystemInfo() (nodeArch, nodeOS, ext string, err error) {
 		return "", "", "", fmt.Errorf("unsupported operating system: %s", osName)
 	}
 
-	// For Windows, perform more accurate architecture detection
-	if osName == "windows" {
-		// First, try to determine if the OS is 32-bit or 64-bit
-		// On Windows, GOARCH might not accurately reflect the OS architecture capability
-		var is64BitOS bool
-
-		// Check if the system is 64-bit capable
-		cmd := exec.Command("powershell", "-Command", "[Environment]::Is64BitOperatingSystem")
-		output, err := cmd.Output()
-		if err == nil {
-			is64BitOS = strings.TrimSpace(string(output)) == "True"
-		} else {
-			// Fallback to GOARCH if PowerShell command fails
-			is64BitOS = runtime.GOARCH == "amd64" || runtime.GOARCH == "arm64"
-		}
-
-		// Check if we're running in a 32-bit process on a 64-bit OS
-		var is32BitProcess bool
-		cmd = exec.Command("powershell", "-Command", "![Environment]::Is64BitProcess")
-		output, err = cmd.Output()
-		if err == nil {
-			is32BitProcess = strings.TrimSpace(string(output)) == "True"
-		} else {
-			is32BitProcess = runtime.GOARCH == "386"
+	if archOverride != "" {
+		nodeArch, err = NormalizeArch(archOverride)
+		if err != nil {
+			return "", "", "", err
 		}
+		return nodeArch, nodeOS, ext, nil
+	}
 
-		// Check for ARM architecture
-		var isARM bool
-		cmd = exec.Command("powershell", "-Command",
-			"(Get-WmiObject -Class Win32_Processor | Select-Object -First 1).Architecture -in @(5, 12)")
-		output, err = cmd.Output()
-		if err == nil {
-			isARM = strings.TrimSpace(string(output)) == "True"
+	// For Windows, map GOARCH directly instead of forcing x86 - x86 Node
+	// builds don't exist at all for Node >= 20 on arm64, and forcing x86
+	// everywhere left real performance on the table for amd64/arm64 hosts.
+	if osName == "windows" {
+		switch runtime.GOARCH {
+		case "amd64":
+			nodeArch = "x64"
+		case "arm64":
+			nodeArch = "arm64"
+		case "386":
+			nodeArch = "x86"
+		default:
+			return "", "", "", fmt.Errorf("unsupported architecture: %s", runtime.GOARCH)
 		}
 
 		fmt.Println("System architecture detection:")
-		fmt.Printf("- 64-bit OS: %v\n", is64BitOS)
-		fmt.Printf("- 32-bit process: %v\n", is32BitProcess)
-		fmt.Printf("- ARM processor: %v\n", isARM)
-
-		// Always use 32-bit (x86) Node.js for better compatibility
-		// This ensures the binaries will work on both 32-bit and 64-bit Windows
-		nodeArch = "x86"
-		fmt.Println("Using x86 architecture for better compatibility")
+		fmt.Printf("- GOARCH: %s\n", runtime.GOARCH)
+		fmt.Printf("- Selected Node.js architecture: %s\n", nodeArch)
 
 	} else {
 		// For macOS and Linux, use standard architecture mapping
@@ -225,3 +263,42 @@ func detectSystemInfo() (nodeArch, nodeOS, ext string, err error) {
 
 	return nodeArch, nodeOS, ext, nil
 }
+
+// archFallbackChain returns, in order, the architectures InstallNodeVersion
+// should retry with when downloading for preferred fails outright - arm64
+// falls back to x64 (under Windows 11's x64 emulation) before x86, and x64
+// falls back to x86; x86 itself has nowhere left to fall back to. preferred
+// is never repeated in its own chain.
+func archFallbackChain(preferred string) []string {
+	switch preferred {
+	case "arm64":
+		return []string{"x64", "x86"}
+	case "x64":
+		return []string{"x86"}
+	default:
+		return nil
+	}
+}
+
+// probeArchiveExists issues a HEAD request against archiveURL and reports
+// whether the server has that architecture's archive at all, so callers can
+// skip straight past a 404 instead of waiting for a full failed download.
+func probeArchiveExists(archiveURL string) bool {
+	settings, err := EffectiveSettings()
+	if err != nil {
+		return true // can't probe - let the real download attempt surface the error
+	}
+	req, err := http.NewRequest(http.MethodHead, archiveURL, nil)
+	if err != nil {
+		return true
+	}
+	req.Header.Set("User-Agent", "node-spark/1.0")
+
+	resp, err := NewHTTPClient(settings).Do(req)
+	if err != nil {
+		return true
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode == http.StatusOK
+}
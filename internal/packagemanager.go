@@ -0,0 +1,467 @@
+// internal/packagemanager.go
+
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/s42yt/node-spark/pkg"
+)
+
+// PackageManager abstracts a package-manager release (npm, pnpm, yarn) so
+// InstallPackageManager/UsePackageManager can manage it independently of any
+// particular Node.js install, mirroring how Runtime in runtime.go manages
+// non-Node JS runtimes.
+type PackageManager interface {
+	// Name identifies the tool for storage paths, config keys, and display.
+	Name() string
+	// BinNames lists the shim executables this tool needs in Node's bin
+	// directory (e.g. npm -> ["npm", "npx"]).
+	BinNames() []string
+	// ShimTarget returns the path of binName's entry point relative to the
+	// tool's install directory, and whether it must be invoked via "node"
+	// (true for JS entry points like npm-cli.js) rather than executed
+	// directly (pnpm's native binary, yarn's bin/yarn.js launcher). A blank
+	// relPath means this tool has nothing to shim for binName.
+	ShimTarget(binName string) (relPath string, viaNode bool)
+	// ResolveVersion turns a spec ("latest" or a concrete version) into a
+	// concrete version string.
+	ResolveVersion(settings *pkg.Settings, spec string) (string, error)
+	// Install downloads and unpacks version into destDir.
+	Install(settings *pkg.Settings, version, destDir string) error
+}
+
+// packageManagers is the registry of tools managed generically by
+// InstallPackageManager/UsePackageManager/CurrentPackageManager.
+var packageManagers = map[string]PackageManager{
+	"npm":  npmPackageManager{},
+	"pnpm": pnpmPackageManager{},
+	"yarn": yarnPackageManager{},
+}
+
+// GetPackageManager looks up a managed package manager by name.
+func GetPackageManager(name string) (PackageManager, error) {
+	pm, ok := packageManagers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown package manager %q (available: npm, pnpm, yarn)", name)
+	}
+	return pm, nil
+}
+
+// pmInstallPath returns the directory a given package manager version is
+// installed into: ~/.node-spark/pm/<tool>/<version>.
+func pmInstallPath(pm PackageManager, version string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".node-spark", "pm", pm.Name(), version), nil
+}
+
+// InstallPackageManager downloads and installs versionSpec ("latest" or a
+// concrete version) for the named package manager, independent of any
+// installed Node.js version.
+func InstallPackageManager(toolName, versionSpec string, config *pkg.Config) error {
+	pm, err := GetPackageManager(toolName)
+	if err != nil {
+		return err
+	}
+
+	settings, err := EffectiveSettings()
+	if err != nil {
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+
+	version, err := pm.ResolveVersion(settings, versionSpec)
+	if err != nil {
+		return err
+	}
+
+	installPath, err := pmInstallPath(pm, version)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(installPath); err == nil {
+		fmt.Printf("%s %s is already installed. Use 'nsk %s use %s' to switch to it.\n", pm.Name(), version, pm.Name(), version)
+		return nil
+	}
+
+	if err := os.MkdirAll(installPath, 0755); err != nil {
+		return fmt.Errorf("failed to create %s version directory: %w", pm.Name(), err)
+	}
+
+	if err := pm.Install(settings, version, installPath); err != nil {
+		os.RemoveAll(installPath)
+		return err
+	}
+
+	fmt.Printf("Successfully installed %s %s.\n", pm.Name(), version)
+	return nil
+}
+
+// UsePackageManager makes toolName@version the active package manager,
+// shimming its binaries into the active Node.js version's bin directory in
+// place of whatever ships with Node, and recording the choice in
+// config.ActivePackageManagers.
+func UsePackageManager(toolName, version string, config *pkg.Config) error {
+	pm, err := GetPackageManager(toolName)
+	if err != nil {
+		return err
+	}
+
+	installPath, err := pmInstallPath(pm, version)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(installPath); os.IsNotExist(err) {
+		return fmt.Errorf("%s %s is not installed; run 'nsk %s install %s' first", pm.Name(), version, pm.Name(), version)
+	}
+
+	if config.ActiveVersion == "" {
+		return fmt.Errorf("no active Node.js version set; run 'nsk use <version>' first")
+	}
+	_, versionDir, err := resolveVersionArchPath(config, config.ActiveVersion, "")
+	if err != nil {
+		return fmt.Errorf("failed to locate active Node.js install: %w", err)
+	}
+	if err := shimPackageManagerBinaries(versionDir, installPath, pm); err != nil {
+		return fmt.Errorf("failed to shim %s binaries: %w", pm.Name(), err)
+	}
+
+	if config.ActivePackageManagers == nil {
+		config.ActivePackageManagers = make(map[string]string)
+	}
+	config.ActivePackageManagers[toolName] = version
+
+	fmt.Printf("Using %s %s (shimmed into Node.js %s).\n", pm.Name(), version, config.ActiveVersion)
+	return nil
+}
+
+// CurrentPackageManager returns the active version of the named package
+// manager.
+func CurrentPackageManager(toolName string, config *pkg.Config) (string, error) {
+	if _, err := GetPackageManager(toolName); err != nil {
+		return "", err
+	}
+
+	version, ok := config.ActivePackageManagers[toolName]
+	if !ok {
+		return "", fmt.Errorf("no active %s version set; run 'nsk %s use <version>' first", toolName, toolName)
+	}
+	return version, nil
+}
+
+// ActivatePackageManager ensures tool@version is installed, installing it
+// first when installMissing is set, then makes it active. It backs
+// ResolveAndActivate, which honors package.json's corepack-style
+// "packageManager" field alongside the Node version it resolves.
+func ActivatePackageManager(tool, version string, config *pkg.Config, installMissing bool) error {
+	pm, err := GetPackageManager(tool)
+	if err != nil {
+		return err
+	}
+
+	installPath, err := pmInstallPath(pm, version)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(installPath); os.IsNotExist(err) {
+		if !installMissing {
+			return fmt.Errorf("%s %s is not installed; run 'nsk %s install %s' first", pm.Name(), version, pm.Name(), version)
+		}
+		if err := InstallPackageManager(tool, version, config); err != nil {
+			return err
+		}
+	}
+
+	return UsePackageManager(tool, version, config)
+}
+
+// shimPackageManagerBinaries (re)writes pm's launchers in versionDir's bin
+// directory, pointing at installDir, mirroring how npmPackageManager's
+// predecessor wired up npm/npx directly but generalized across tools whose
+// entry points may be JS files that need "node" in front of them (npm,
+// yarn) or already-executable native binaries (pnpm).
+func shimPackageManagerBinaries(versionDir, installDir string, pm PackageManager) error {
+	if runtime.GOOS == "windows" {
+		return shimPackageManagerBinariesWindows(versionDir, installDir, pm)
+	}
+	return shimPackageManagerBinariesPosix(versionDir, installDir, pm)
+}
+
+func shimPackageManagerBinariesPosix(versionDir, installDir string, pm PackageManager) error {
+	binDir := filepath.Join(versionDir, "bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		return err
+	}
+
+	for _, binName := range pm.BinNames() {
+		relTarget, viaNode := pm.ShimTarget(binName)
+		if relTarget == "" {
+			continue
+		}
+
+		relInstallTarget, err := filepath.Rel(binDir, filepath.Join(installDir, relTarget))
+		if err != nil {
+			return err
+		}
+
+		var script string
+		if viaNode {
+			script = fmt.Sprintf("#!/bin/sh\nbasedir=$(dirname \"$(readlink -f \"$0\" 2>/dev/null || echo \"$0\")\")\nexec \"$basedir/node\" \"$basedir/%s\" \"$@\"\n", relInstallTarget)
+		} else {
+			script = fmt.Sprintf("#!/bin/sh\nbasedir=$(dirname \"$(readlink -f \"$0\" 2>/dev/null || echo \"$0\")\")\nexec \"$basedir/%s\" \"$@\"\n", relInstallTarget)
+		}
+
+		shimPath := filepath.Join(binDir, binName)
+		os.Remove(shimPath) // may be a symlink or shim from a previous tool
+		if err := os.WriteFile(shimPath, []byte(script), 0755); err != nil {
+			return fmt.Errorf("failed to write %s shim: %w", binName, err)
+		}
+	}
+
+	return nil
+}
+
+func shimPackageManagerBinariesWindows(versionDir, installDir string, pm PackageManager) error {
+	for _, binName := range pm.BinNames() {
+		relTarget, viaNode := pm.ShimTarget(binName)
+		if relTarget == "" {
+			continue
+		}
+
+		relInstallTarget, err := filepath.Rel(versionDir, filepath.Join(installDir, relTarget))
+		if err != nil {
+			return err
+		}
+
+		var script string
+		if viaNode {
+			script = fmt.Sprintf("@echo off\r\n\"%%~dp0node.exe\" \"%%~dp0%s\" %%*\r\n", relInstallTarget)
+		} else {
+			script = fmt.Sprintf("@echo off\r\n\"%%~dp0%s\" %%*\r\n", relInstallTarget)
+		}
+
+		shimPath := filepath.Join(versionDir, binName+".cmd")
+		if err := os.WriteFile(shimPath, []byte(script), 0755); err != nil {
+			return fmt.Errorf("failed to write %s shim: %w", binName, err)
+		}
+	}
+
+	return nil
+}
+
+// --- npm ---
+
+// npmDistTags is the subset of the registry's GET /npm response this package
+// needs to resolve the "latest" tag to a concrete version.
+type npmDistTags struct {
+	DistTags map[string]string `json:"dist-tags"`
+}
+
+// npmPackageManager serves npm releases as plain registry tarballs.
+type npmPackageManager struct{}
+
+func (npmPackageManager) Name() string       { return "npm" }
+func (npmPackageManager) BinNames() []string { return []string{"npm", "npx"} }
+
+func (npmPackageManager) ShimTarget(binName string) (string, bool) {
+	switch binName {
+	case "npm":
+		return filepath.Join("bin", "npm-cli.js"), true
+	case "npx":
+		return filepath.Join("bin", "npx-cli.js"), true
+	default:
+		return "", false
+	}
+}
+
+func (npmPackageManager) ResolveVersion(settings *pkg.Settings, spec string) (string, error) {
+	if strings.EqualFold(spec, "latest") {
+		return resolveNpmDistTag(settings, "latest")
+	}
+	return strings.TrimPrefix(spec, "v"), nil
+}
+
+func (npmPackageManager) Install(settings *pkg.Settings, version, destDir string) error {
+	tarballURL := fmt.Sprintf("%s/npm/-/npm-%s.tgz", NpmRegistryBaseURL(settings), version)
+	archivePath := filepath.Join(os.TempDir(), fmt.Sprintf("npm-%s.tgz", version))
+
+	fmt.Printf("Downloading npm %s from %s...\n", version, tarballURL)
+	if _, err := DownloadFile(archivePath, tarballURL); err != nil {
+		return fmt.Errorf("failed to download npm %s: %w", version, err)
+	}
+	defer os.Remove(archivePath)
+
+	// npm's tarball wraps everything in a "package/" directory, which
+	// ExtractArchive strips, landing bin/package.json directly in destDir.
+	return ExtractArchive(archivePath, destDir)
+}
+
+// resolveNpmDistTag queries the registry's package metadata for npm and
+// returns the concrete version behind the given dist-tag (e.g. "latest").
+func resolveNpmDistTag(settings *pkg.Settings, tag string) (string, error) {
+	url := NpmRegistryBaseURL(settings) + "/npm"
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create registry request: %w", err)
+	}
+	req.Header.Set("User-Agent", "node-spark/1.0")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := NewHTTPClient(settings).Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to query npm registry: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to query npm registry: status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read npm registry response: %w", err)
+	}
+
+	var tags npmDistTags
+	if err := json.Unmarshal(body, &tags); err != nil {
+		return "", fmt.Errorf("failed to parse npm registry response: %w", err)
+	}
+
+	version, ok := tags.DistTags[tag]
+	if !ok || version == "" {
+		return "", fmt.Errorf("npm registry has no dist-tag %q", tag)
+	}
+
+	return version, nil
+}
+
+// --- pnpm ---
+
+// pnpmPackageManager serves pnpm's single-binary GitHub releases.
+type pnpmPackageManager struct{}
+
+func (pnpmPackageManager) Name() string       { return "pnpm" }
+func (pnpmPackageManager) BinNames() []string { return []string{"pnpm"} }
+
+func (pnpmPackageManager) ShimTarget(binName string) (string, bool) {
+	if binName != "pnpm" {
+		return "", false
+	}
+	name := "pnpm"
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return filepath.Join("bin", name), false
+}
+
+func (pnpmPackageManager) ResolveVersion(settings *pkg.Settings, spec string) (string, error) {
+	if strings.EqualFold(spec, "latest") {
+		tag, err := fetchGithubLatestTag(settings, "pnpm/pnpm")
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimPrefix(tag, "v"), nil
+	}
+	return strings.TrimPrefix(spec, "v"), nil
+}
+
+func (pnpmPackageManager) Install(settings *pkg.Settings, version, destDir string) error {
+	asset, err := pnpmReleaseAsset()
+	if err != nil {
+		return err
+	}
+	filename := "pnpm-" + asset
+	url := fmt.Sprintf("https://github.com/pnpm/pnpm/releases/download/v%s/%s", version, filename)
+
+	binDir := filepath.Join(destDir, "bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		return err
+	}
+	binName := "pnpm"
+	if runtime.GOOS == "windows" {
+		binName += ".exe"
+	}
+	binPath := filepath.Join(binDir, binName)
+
+	fmt.Printf("Downloading pnpm %s from %s...\n", version, url)
+	if _, err := DownloadFile(binPath, url); err != nil {
+		return fmt.Errorf("failed to download pnpm %s: %w", version, err)
+	}
+
+	return os.Chmod(binPath, 0755)
+}
+
+// pnpmReleaseAsset returns the "<os>-<arch>[.exe]" suffix pnpm appends to its
+// release binaries for the current platform.
+func pnpmReleaseAsset() (string, error) {
+	switch runtime.GOOS {
+	case "linux":
+		if runtime.GOARCH == "arm64" {
+			return "linux-arm64", nil
+		}
+		return "linux-x64", nil
+	case "darwin":
+		if runtime.GOARCH == "arm64" {
+			return "macos-arm64", nil
+		}
+		return "macos-x64", nil
+	case "windows":
+		return "win-x64.exe", nil
+	default:
+		return "", fmt.Errorf("unsupported operating system for pnpm: %s", runtime.GOOS)
+	}
+}
+
+// --- yarn ---
+
+// yarnPackageManager serves Yarn Classic's (1.x) GitHub release tarballs.
+type yarnPackageManager struct{}
+
+func (yarnPackageManager) Name() string       { return "yarn" }
+func (yarnPackageManager) BinNames() []string { return []string{"yarn", "yarnpkg"} }
+
+func (yarnPackageManager) ShimTarget(binName string) (string, bool) {
+	switch binName {
+	case "yarn", "yarnpkg":
+		return filepath.Join("bin", "yarn.js"), true
+	default:
+		return "", false
+	}
+}
+
+func (yarnPackageManager) ResolveVersion(settings *pkg.Settings, spec string) (string, error) {
+	if strings.EqualFold(spec, "latest") {
+		tag, err := fetchGithubLatestTag(settings, "yarnpkg/yarn")
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimPrefix(tag, "v"), nil
+	}
+	return strings.TrimPrefix(spec, "v"), nil
+}
+
+func (yarnPackageManager) Install(settings *pkg.Settings, version, destDir string) error {
+	filename := fmt.Sprintf("yarn-v%s.tar.gz", version)
+	url := fmt.Sprintf("https://github.com/yarnpkg/yarn/releases/download/v%s/%s", version, filename)
+	archivePath := filepath.Join(os.TempDir(), filename)
+
+	fmt.Printf("Downloading yarn %s from %s...\n", version, url)
+	if _, err := DownloadFile(archivePath, url); err != nil {
+		return fmt.Errorf("failed to download yarn %s: %w", version, err)
+	}
+	defer os.Remove(archivePath)
+
+	// The release tarball wraps everything in a "yarn-vX.Y.Z/" directory,
+	// which ExtractArchive strips, landing bin/yarn.js directly in destDir.
+	return ExtractArchive(archivePath, destDir)
+}
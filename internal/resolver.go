@@ -0,0 +1,320 @@
+// internal/resolver.go
+
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/s42yt/node-spark/pkg"
+)
+
+// FindProjectVersionSpec walks up from startDir looking for a `.nvmrc`,
+// `.node-version`, or a `package.json` with an `engines.node` range, via the
+// pluggable pkg.VersionDetector chain in autoswitch.go. It returns the raw
+// spec found (e.g. "18.17.0", "^20", "lts/hydrogen") and the name of the
+// detector it came from. If nothing is found, it returns an empty spec.
+func FindProjectVersionSpec(startDir string) (spec string, source string, err error) {
+	return DetectProjectVersionSpec(startDir, DefaultVersionDetectors())
+}
+
+// ResolveVersionSpec resolves a raw version spec against the versions
+// installed in config, returning the best matching installed version. A spec
+// may be a concrete version, a semver range, a `lts`/`lts/<codename>` or
+// `latest`/`node` alias, a user-defined name from config.Aliases, or
+// `system` (returned as-is; see SystemAlias). If installMissing is true and
+// no installed version satisfies the spec, it resolves against the remote
+// index and installs the best match; otherwise the remote index is still
+// consulted read-only when needed to resolve `latest`/`lts` aliases, but the
+// result must already be installed.
+func ResolveVersionSpec(spec string, config *pkg.Config, installMissing bool) (string, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return "", fmt.Errorf("empty version spec")
+	}
+
+	if strings.EqualFold(spec, SystemAlias) {
+		return SystemAlias, nil
+	}
+
+	if alias, ok := config.Aliases[spec]; ok {
+		spec = alias
+	}
+
+	if match := bestInstalledMatch(spec, config.InstalledVersions, nil); match != "" {
+		return match, nil
+	}
+
+	remote, err := FetchAvailableVersions()
+	if err != nil {
+		if !installMissing {
+			return "", fmt.Errorf("no installed Node.js version satisfies %q (use --install-missing to install one)", spec)
+		}
+		return "", fmt.Errorf("failed to resolve %q: %w", spec, err)
+	}
+
+	remoteVersions := make([]string, len(remote))
+	for i, v := range remote {
+		remoteVersions[i] = v.CleanVersion()
+	}
+
+	match := bestInstalledMatch(spec, remoteVersions, remote)
+	if match == "" {
+		return "", fmt.Errorf("no available Node.js version satisfies %q", spec)
+	}
+
+	if !installMissing {
+		for _, v := range config.InstalledVersions {
+			if v == match {
+				return match, nil
+			}
+		}
+		return "", fmt.Errorf("%s (resolved from %q) is not installed (use --install-missing to install it)", match, spec)
+	}
+
+	if _, err := InstallNodeVersion(match, config, false, "", true); err != nil {
+		return "", fmt.Errorf("failed to install resolved version %s: %w", match, err)
+	}
+
+	return match, nil
+}
+
+// RemoteVersionFilter narrows down the versions returned by
+// FetchAvailableVersions for `list-remote`. A zero-value filter matches
+// everything. Major is ignored when zero; LTSCodename "*" means "any LTS".
+type RemoteVersionFilter struct {
+	LTSOnly     bool
+	LTSCodename string
+	Major       int
+	// Range holds a space-separated list of clauses like ">=18 <21", every
+	// one of which a version must satisfy.
+	Range string
+	Limit int
+}
+
+// FilterRemoteVersions applies f to versions, which is expected to already be
+// sorted newest-first (as FetchAvailableVersions returns it), and truncates
+// to f.Limit when positive.
+func FilterRemoteVersions(versions []NodeVersion, f RemoteVersionFilter) []NodeVersion {
+	var filtered []NodeVersion
+	for _, v := range versions {
+		if f.LTSOnly || f.LTSCodename != "" {
+			if !v.IsLTS() {
+				continue
+			}
+			if f.LTSCodename != "" && f.LTSCodename != "*" && !strings.EqualFold(v.LTSName(), f.LTSCodename) {
+				continue
+			}
+		}
+
+		if f.Major > 0 {
+			parts := extractVersionNumbers(v.Version)
+			if len(parts) == 0 || parts[0] != f.Major {
+				continue
+			}
+		}
+
+		if f.Range != "" && !versionSatisfiesRange(v.CleanVersion(), f.Range) {
+			continue
+		}
+
+		filtered = append(filtered, v)
+	}
+
+	if f.Limit > 0 && len(filtered) > f.Limit {
+		filtered = filtered[:f.Limit]
+	}
+
+	return filtered
+}
+
+// versionSatisfiesRange reports whether candidate satisfies every
+// whitespace-separated clause in rangeSpec, e.g. ">=18 <21".
+func versionSatisfiesRange(candidate, rangeSpec string) bool {
+	for _, clause := range strings.Fields(rangeSpec) {
+		op, bound := splitRangeOperator(clause)
+		if !versionSatisfies(candidate, op, bound) {
+			return false
+		}
+	}
+	return true
+}
+
+// bestInstalledMatch returns the highest version in candidates that satisfies
+// spec. lts aliases are resolved against remote when it is provided (nil when
+// matching purely against already-installed versions, which carry no LTS
+// metadata).
+func bestInstalledMatch(spec string, candidates []string, remote []NodeVersion) string {
+	lower := strings.ToLower(spec)
+
+	if lower == "latest" || lower == "node" {
+		return newestVersion(candidates)
+	}
+
+	if lower == "lts" || lower == "lts/*" || strings.HasPrefix(lower, "lts/") {
+		codename := strings.TrimPrefix(lower, "lts/")
+		return bestLTSMatch(codename, remote)
+	}
+
+	op, bound := splitRangeOperator(spec)
+
+	var best string
+	for _, candidate := range candidates {
+		if !versionSatisfies(candidate, op, bound) {
+			continue
+		}
+		if best == "" || compareVersions(candidate, best) > 0 {
+			best = candidate
+		}
+	}
+
+	return best
+}
+
+// newestVersion returns the highest version in candidates, or "" if it's
+// empty, backing the `latest`/`node` aliases.
+func newestVersion(candidates []string) string {
+	var best string
+	for _, candidate := range candidates {
+		if best == "" || compareVersions(candidate, best) > 0 {
+			best = candidate
+		}
+	}
+	return best
+}
+
+// bestLTSMatch finds the newest remote version whose LTS codename matches
+// (or, for "*"/empty, the newest LTS release at all).
+func bestLTSMatch(codename string, remote []NodeVersion) string {
+	var best NodeVersion
+	for _, v := range remote {
+		if !v.IsLTS() {
+			continue
+		}
+		if codename != "" && codename != "*" && !strings.EqualFold(v.LTSName(), codename) {
+			continue
+		}
+		if best.Version == "" || compareVersions(v.CleanVersion(), best.CleanVersion()) > 0 {
+			best = v
+		}
+	}
+	return best.CleanVersion()
+}
+
+// splitRangeOperator splits a semver range like "^20.11.0", "~20", ">=18",
+// or "<21" into its operator and bound. A bare version has an empty operator.
+func splitRangeOperator(spec string) (op, bound string) {
+	for _, candidate := range []string{">=", "<=", "^", "~", ">", "<"} {
+		if strings.HasPrefix(spec, candidate) {
+			return candidate, strings.TrimPrefix(spec, candidate)
+		}
+	}
+	return "", strings.TrimSuffix(spec, ".x")
+}
+
+// versionSatisfies reports whether candidate satisfies the range described by
+// op and bound, using the same caret/tilde semantics as npm's semver: "^"
+// allows any change that does not bump the left-most non-zero component
+// (e.g. "^20.11.0" matches up to, but excluding, 21.0.0), and "~" allows only
+// patch-level changes (e.g. "~20.11.0" matches up to, but excluding, 20.12.0).
+func versionSatisfies(candidate, op, bound string) bool {
+	candidate = strings.TrimPrefix(candidate, "v")
+	bound = strings.TrimPrefix(bound, "v")
+
+	switch op {
+	case "":
+		return versionHasPrefix(candidate, bound)
+	case "^":
+		return compareVersions(candidate, bound) >= 0 && compareVersions(candidate, caretUpperBound(bound)) < 0
+	case "~":
+		return compareVersions(candidate, bound) >= 0 && compareVersions(candidate, tildeUpperBound(bound)) < 0
+	case ">=":
+		return compareVersions(candidate, bound) >= 0
+	case ">":
+		return compareVersions(candidate, bound) > 0
+	case "<=":
+		return compareVersions(candidate, bound) <= 0
+	case "<":
+		return compareVersions(candidate, bound) < 0
+	default:
+		return false
+	}
+}
+
+// caretUpperBound returns the exclusive upper bound for a caret range on
+// bound: it holds the left-most non-zero component fixed and bumps the next
+// one, e.g. "20.11.0" -> "21.0.0", "0.11.0" -> "0.12.0", "0.0.5" -> "0.0.6".
+// A bound with fewer than 3 components is padded with zeros first, so "^20"
+// behaves like "^20.0.0".
+func caretUpperBound(bound string) string {
+	parts := extractVersionNumbers(bound)
+	for len(parts) < 3 {
+		parts = append(parts, 0)
+	}
+
+	if parts[0] > 0 {
+		return fmt.Sprintf("%d.0.0", parts[0]+1)
+	}
+	if parts[1] > 0 {
+		return fmt.Sprintf("0.%d.0", parts[1]+1)
+	}
+	return fmt.Sprintf("0.0.%d", parts[2]+1)
+}
+
+// tildeUpperBound returns the exclusive upper bound for a tilde range on
+// bound: it bumps the minor component, e.g. "20.11.0" -> "20.12.0". A bound
+// with no minor component (e.g. "~20") behaves like a caret range, bumping
+// the major instead.
+func tildeUpperBound(bound string) string {
+	parts := extractVersionNumbers(bound)
+	if len(parts) < 2 {
+		major := 0
+		if len(parts) == 1 {
+			major = parts[0]
+		}
+		return fmt.Sprintf("%d.0.0", major+1)
+	}
+	return fmt.Sprintf("%d.%d.0", parts[0], parts[1]+1)
+}
+
+// versionHasPrefix reports whether candidate's leading numeric components
+// match bound's, so "20" matches "20.11.0" and "20.11" matches "20.11.0" but
+// not "20.12.0".
+func versionHasPrefix(candidate, bound string) bool {
+	candidateParts := extractVersionNumbers(candidate)
+	boundParts := extractVersionNumbers(bound)
+
+	if len(boundParts) > len(candidateParts) {
+		return false
+	}
+	for i, part := range boundParts {
+		if candidateParts[i] != part {
+			return false
+		}
+	}
+	return true
+}
+
+// compareVersions compares two dotted version strings numerically,
+// returning -1, 0, or 1.
+func compareVersions(a, b string) int {
+	aParts := extractVersionNumbers(a)
+	bParts := extractVersionNumbers(b)
+
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		if aParts[i] != bParts[i] {
+			if aParts[i] > bParts[i] {
+				return 1
+			}
+			return -1
+		}
+	}
+
+	if len(aParts) == len(bParts) {
+		return 0
+	}
+	if len(aParts) > len(bParts) {
+		return 1
+	}
+	return -1
+}
@@ -0,0 +1,46 @@
+package internal
+
+import "testing"
+
+func TestVersionSatisfies(t *testing.T) {
+	cases := []struct {
+		candidate string
+		op        string
+		bound     string
+		want      bool
+	}{
+		// caret: compatible-major range.
+		{"20.11.0", "^", "20.11.0", true},
+		{"20.12.0", "^", "20.11.0", true},
+		{"20.11.5", "^", "20.11.0", true},
+		{"21.0.0", "^", "20.11.0", false},
+		{"20.10.0", "^", "20.11.0", false},
+		{"0.12.0", "^", "0.11.0", false},
+		{"0.11.5", "^", "0.11.0", true},
+		{"0.0.6", "^", "0.0.5", false},
+		{"0.0.5", "^", "0.0.5", true},
+		{"20.5.0", "^", "20", true},
+		{"21.0.0", "^", "20", false},
+
+		// tilde: patch-only range.
+		{"20.11.5", "~", "20.11.0", true},
+		{"20.12.0", "~", "20.11.0", false},
+		{"20.11.0", "~", "20.11.0", true},
+		{"21.0.0", "~", "20", false},
+		{"20.9.0", "~", "20", true},
+
+		// bare version and comparison operators, unaffected by the fix.
+		{"20.11.0", "", "20.11", true},
+		{"20.12.0", "", "20.11", false},
+		{"20.11.0", ">=", "20.11.0", true},
+		{"20.10.0", ">=", "20.11.0", false},
+		{"20.11.0", "<", "21.0.0", true},
+	}
+
+	for _, tc := range cases {
+		got := versionSatisfies(tc.candidate, tc.op, tc.bound)
+		if got != tc.want {
+			t.Errorf("versionSatisfies(%q, %q, %q) = %v, want %v", tc.candidate, tc.op, tc.bound, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,472 @@
+// internal/runtime.go
+
+package internal
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/s42yt/node-spark/pkg"
+)
+
+// Runtime abstracts a single-binary JS runtime (deno, bun) or a Node-like
+// tarball runtime (io.js) so InstallRuntime/UseRuntime can manage them
+// side by side with Node itself, which keeps using its own dedicated
+// InstallNodeVersion/UseVersion pipeline (registered here mostly so --runtime
+// flags have a consistent name to dispatch on).
+type Runtime interface {
+	// Name identifies the runtime for --runtime flags and display purposes.
+	Name() string
+	// BinaryName is the executable's name once installed (without .exe).
+	BinaryName() string
+	// ResolveVersion turns a spec ("latest" or a concrete version) into a
+	// concrete version string.
+	ResolveVersion(settings *pkg.Settings, spec string) (string, error)
+	// DownloadURL returns the archive URL and filename for a concrete version.
+	DownloadURL(settings *pkg.Settings, version string) (url, filename string, err error)
+}
+
+// runtimes is the registry of non-Node runtimes managed generically by
+// InstallRuntime/UseRuntime/CurrentRuntime.
+var runtimes = map[string]Runtime{
+	"iojs": ioJSRuntime{},
+	"deno": denoRuntime{},
+	"bun":  bunRuntime{},
+}
+
+// GetRuntime looks up a non-Node runtime by name.
+func GetRuntime(name string) (Runtime, error) {
+	rt, ok := runtimes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown runtime %q (available: node, iojs, deno, bun)", name)
+	}
+	return rt, nil
+}
+
+// runtimeInstallPath returns the directory a given runtime version is
+// installed into: ~/.node-spark/runtimes/<name>/<version>.
+func runtimeInstallPath(rt Runtime, version string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".node-spark", "runtimes", rt.Name(), version), nil
+}
+
+// runtimeCurrentLinkPath returns the shared "current" symlink/junction for a
+// runtime, analogous to currentLinkPath for Node.
+func runtimeCurrentLinkPath(rt Runtime) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".node-spark", "runtimes", rt.Name(), "current"), nil
+}
+
+// InstallRuntime downloads and installs versionSpec ("latest" or a concrete
+// version) for the named non-Node runtime, recording it in config.Runtimes.
+func InstallRuntime(runtimeName, versionSpec string, config *pkg.Config) error {
+	rt, err := GetRuntime(runtimeName)
+	if err != nil {
+		return err
+	}
+
+	settings, err := EffectiveSettings()
+	if err != nil {
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+
+	version, err := rt.ResolveVersion(settings, versionSpec)
+	if err != nil {
+		return err
+	}
+
+	state := config.Runtimes[runtimeName]
+	for _, v := range state.InstalledVersions {
+		if v == version {
+			fmt.Printf("%s %s is already installed. Use 'nsk use %s@%s' to switch to it.\n", rt.Name(), version, rt.Name(), version)
+			return nil
+		}
+	}
+
+	installPath, err := runtimeInstallPath(rt, version)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(installPath, 0755); err != nil {
+		return fmt.Errorf("failed to create %s version directory: %w", rt.Name(), err)
+	}
+
+	url, filename, err := rt.DownloadURL(settings, version)
+	if err != nil {
+		return err
+	}
+
+	archivePath := filepath.Join(os.TempDir(), filename)
+	fmt.Printf("Downloading %s %s from %s...\n", rt.Name(), version, url)
+	if _, err := DownloadFile(archivePath, url); err != nil {
+		return fmt.Errorf("failed to download %s %s: %w", rt.Name(), version, err)
+	}
+	defer os.Remove(archivePath)
+	fmt.Println("Download complete.")
+
+	fmt.Printf("Extracting %s to %s...\n", filename, installPath)
+	if err := extractRuntimeArchive(archivePath, installPath, rt.BinaryName()); err != nil {
+		return fmt.Errorf("failed to extract %s archive: %w", rt.Name(), err)
+	}
+	fmt.Println("Extraction complete.")
+
+	state.InstalledVersions = append(state.InstalledVersions, version)
+	if config.Runtimes == nil {
+		config.Runtimes = make(map[string]pkg.RuntimeState)
+	}
+	config.Runtimes[runtimeName] = state
+
+	fmt.Printf("Successfully installed %s %s.\n", rt.Name(), version)
+	return nil
+}
+
+// UseRuntime switches the active version of the named non-Node runtime by
+// atomically retargeting its "current" symlink/junction, mirroring UseVersion.
+func UseRuntime(runtimeName, version string, config *pkg.Config) error {
+	rt, err := GetRuntime(runtimeName)
+	if err != nil {
+		return err
+	}
+
+	versionPath, err := runtimeInstallPath(rt, version)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(versionPath); os.IsNotExist(err) {
+		return fmt.Errorf("%s %s is not installed", rt.Name(), version)
+	}
+
+	linkPath, err := runtimeCurrentLinkPath(rt)
+	if err != nil {
+		return err
+	}
+
+	if runtime.GOOS == "windows" {
+		if err := atomicJunction(versionPath, linkPath); err != nil {
+			return fmt.Errorf("failed to switch %s version: %w", rt.Name(), err)
+		}
+	} else {
+		if err := atomicSymlink(versionPath, linkPath); err != nil {
+			return fmt.Errorf("failed to switch %s version: %w", rt.Name(), err)
+		}
+	}
+
+	state := config.Runtimes[runtimeName]
+	state.ActiveVersion = version
+	if config.Runtimes == nil {
+		config.Runtimes = make(map[string]pkg.RuntimeState)
+	}
+	config.Runtimes[runtimeName] = state
+
+	fmt.Printf("Successfully switched to %s %s\n", rt.Name(), version)
+	fmt.Printf("Make sure %s is in your PATH (only needs to be added once).\n", linkPath)
+	return nil
+}
+
+// CurrentRuntime returns the active version of the named non-Node runtime.
+func CurrentRuntime(runtimeName string, config *pkg.Config) (string, error) {
+	if _, err := GetRuntime(runtimeName); err != nil {
+		return "", err
+	}
+
+	state, ok := config.Runtimes[runtimeName]
+	if !ok || state.ActiveVersion == "" {
+		return "", fmt.Errorf("no active %s version set", runtimeName)
+	}
+	return state.ActiveVersion, nil
+}
+
+// extractRuntimeArchive extracts a single-binary runtime's archive into
+// destDir. Unlike ExtractArchive, it does not assume or strip a top-level
+// directory, since deno/bun releases vary (flat zip vs one nested folder);
+// instead it extracts everything as-is, then locates binaryName (plus .exe
+// on Windows) anywhere in the result and ensures it ends up at destDir's
+// root, executable.
+func extractRuntimeArchive(archivePath, destDir, binaryName string) error {
+	ext := filepath.Ext(archivePath)
+
+	if ext == ".zip" {
+		if err := extractFlatZip(archivePath, destDir); err != nil {
+			return err
+		}
+	} else if ext == ".gz" && strings.HasSuffix(strings.TrimSuffix(archivePath, ext), ".tar") {
+		file, err := os.Open(archivePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		if err := extractTarGz(file, destDir); err != nil {
+			return err
+		}
+	} else {
+		return fmt.Errorf("unsupported archive format: %s", ext)
+	}
+
+	wantName := binaryName
+	if runtime.GOOS == "windows" {
+		wantName += ".exe"
+	}
+
+	binPath := filepath.Join(destDir, wantName)
+	if _, err := os.Stat(binPath); err == nil {
+		return os.Chmod(binPath, 0755)
+	}
+
+	var found string
+	filepath.Walk(destDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || found != "" {
+			return nil
+		}
+		if !info.IsDir() && info.Name() == wantName {
+			found = path
+			return filepath.SkipAll
+		}
+		return nil
+	})
+
+	if found == "" {
+		return fmt.Errorf("%s not found in extracted archive", wantName)
+	}
+
+	data, err := os.ReadFile(found)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(binPath, data, 0755); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// extractFlatZip extracts every entry of a zip archive into destDir
+// preserving its internal relative paths, with no top-level-directory
+// stripping (used for single-binary runtime releases rather than Node's
+// archive layout).
+func extractFlatZip(archivePath, destDir string) error {
+	zipReader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer zipReader.Close()
+
+	cleanedDest := filepath.Clean(destDir)
+
+	for _, file := range zipReader.File {
+		target := filepath.Join(destDir, filepath.FromSlash(file.Name))
+		cleanedTarget := filepath.Clean(target)
+		if cleanedTarget != cleanedDest && !strings.HasPrefix(cleanedTarget, cleanedDest+string(os.PathSeparator)) {
+			fmt.Printf("Warning: Skipping potentially unsafe path: %s\n", file.Name)
+			continue
+		}
+
+		if file.FileInfo().IsDir() {
+			if err := os.MkdirAll(cleanedTarget, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(cleanedTarget), 0755); err != nil {
+			return err
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			return err
+		}
+		outFile, err := os.OpenFile(cleanedTarget, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, file.Mode())
+		if err != nil {
+			rc.Close()
+			return err
+		}
+		_, copyErr := io.Copy(outFile, rc)
+		rc.Close()
+		outFile.Close()
+		if copyErr != nil {
+			return copyErr
+		}
+	}
+
+	return nil
+}
+
+// githubLatestRelease is the subset of GitHub's release API response needed
+// to resolve "latest" to a concrete tag.
+type githubLatestRelease struct {
+	TagName string `json:"tag_name"`
+}
+
+// fetchGithubLatestTag queries the GitHub releases API for repo's latest
+// release tag (e.g. "v1.46.3" or "bun-v1.1.0").
+func fetchGithubLatestTag(settings *pkg.Settings, repo string) (string, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", repo)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create GitHub API request: %w", err)
+	}
+	req.Header.Set("User-Agent", "node-spark/1.0")
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := NewHTTPClient(settings).Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to query GitHub releases for %s: %w", repo, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to query GitHub releases for %s: status code %d", repo, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read GitHub API response: %w", err)
+	}
+
+	var release githubLatestRelease
+	if err := json.Unmarshal(body, &release); err != nil {
+		return "", fmt.Errorf("failed to parse GitHub API response: %w", err)
+	}
+	if release.TagName == "" {
+		return "", fmt.Errorf("GitHub API returned no tag_name for %s", repo)
+	}
+
+	return release.TagName, nil
+}
+
+// --- io.js ---
+
+// ioJSRuntime serves io.js, the pre-merger Node.js fork, from iojs.org. Its
+// tarballs share Node's exact archive layout, so extraction reuses
+// extractTarGz's top-level-directory stripping.
+type ioJSRuntime struct{}
+
+func (ioJSRuntime) Name() string       { return "iojs" }
+func (ioJSRuntime) BinaryName() string { return "iojs" }
+
+func (ioJSRuntime) ResolveVersion(settings *pkg.Settings, spec string) (string, error) {
+	if strings.EqualFold(spec, "latest") {
+		return "", fmt.Errorf("io.js reached end-of-life in 2016; specify a concrete version (e.g. 3.3.1)")
+	}
+	return strings.TrimPrefix(spec, "v"), nil
+}
+
+func (ioJSRuntime) DownloadURL(settings *pkg.Settings, version string) (string, string, error) {
+	nodeArch, nodeOS, ext, err := detectSystemInfo("")
+	if err != nil {
+		return "", "", err
+	}
+	filename := fmt.Sprintf("iojs-v%s-%s-%s.%s", version, nodeOS, nodeArch, ext)
+	url := fmt.Sprintf("https://iojs.org/dist/v%s/%s", version, filename)
+	return url, filename, nil
+}
+
+// --- Deno ---
+
+// denoRuntime serves Deno releases from GitHub.
+type denoRuntime struct{}
+
+func (denoRuntime) Name() string       { return "deno" }
+func (denoRuntime) BinaryName() string { return "deno" }
+
+func (denoRuntime) ResolveVersion(settings *pkg.Settings, spec string) (string, error) {
+	if strings.EqualFold(spec, "latest") {
+		tag, err := fetchGithubLatestTag(settings, "denoland/deno")
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimPrefix(tag, "v"), nil
+	}
+	return strings.TrimPrefix(spec, "v"), nil
+}
+
+func (denoRuntime) DownloadURL(settings *pkg.Settings, version string) (string, string, error) {
+	target, err := denoReleaseTarget()
+	if err != nil {
+		return "", "", err
+	}
+	filename := fmt.Sprintf("deno-%s.zip", target)
+	url := fmt.Sprintf("https://github.com/denoland/deno/releases/download/v%s/%s", version, filename)
+	return url, filename, nil
+}
+
+func denoReleaseTarget() (string, error) {
+	switch runtime.GOOS {
+	case "linux":
+		if runtime.GOARCH == "arm64" {
+			return "aarch64-unknown-linux-gnu", nil
+		}
+		return "x86_64-unknown-linux-gnu", nil
+	case "darwin":
+		if runtime.GOARCH == "arm64" {
+			return "aarch64-apple-darwin", nil
+		}
+		return "x86_64-apple-darwin", nil
+	case "windows":
+		return "x86_64-pc-windows-msvc", nil
+	default:
+		return "", fmt.Errorf("unsupported operating system for deno: %s", runtime.GOOS)
+	}
+}
+
+// --- Bun ---
+
+// bunRuntime serves Bun releases from GitHub.
+type bunRuntime struct{}
+
+func (bunRuntime) Name() string       { return "bun" }
+func (bunRuntime) BinaryName() string { return "bun" }
+
+func (bunRuntime) ResolveVersion(settings *pkg.Settings, spec string) (string, error) {
+	if strings.EqualFold(spec, "latest") {
+		tag, err := fetchGithubLatestTag(settings, "oven-sh/bun")
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimPrefix(tag, "bun-v"), nil
+	}
+	return strings.TrimPrefix(spec, "v"), nil
+}
+
+func (bunRuntime) DownloadURL(settings *pkg.Settings, version string) (string, string, error) {
+	target, err := bunReleaseTarget()
+	if err != nil {
+		return "", "", err
+	}
+	filename := fmt.Sprintf("bun-%s.zip", target)
+	url := fmt.Sprintf("https://github.com/oven-sh/bun/releases/download/bun-v%s/%s", version, filename)
+	return url, filename, nil
+}
+
+func bunReleaseTarget() (string, error) {
+	switch runtime.GOOS {
+	case "linux":
+		if runtime.GOARCH == "arm64" {
+			return "linux-aarch64", nil
+		}
+		return "linux-x64", nil
+	case "darwin":
+		if runtime.GOARCH == "arm64" {
+			return "darwin-aarch64", nil
+		}
+		return "darwin-x64", nil
+	case "windows":
+		return "windows-x64", nil
+	default:
+		return "", fmt.Errorf("unsupported operating system for bun: %s", runtime.GOOS)
+	}
+}
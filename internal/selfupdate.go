@@ -0,0 +1,218 @@
+// internal/selfupdate.go
+
+package internal
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/s42yt/node-spark/pkg"
+)
+
+// selfUpdateManifestURL returns the latest.json URL node-spark's own release
+// process publishes for the given channel ("stable" or "beta"; empty means
+// "stable"), modeled on gnvm's self-update flow.
+func selfUpdateManifestURL(channel string) string {
+	tag := "latest"
+	if channel == "beta" {
+		tag = "beta"
+	}
+	return fmt.Sprintf("https://github.com/S42yt/node-spark/releases/download/%s/latest.json", tag)
+}
+
+// httpUpdater is the production pkg.Updater, backed by node-spark's GitHub
+// Releases.
+type httpUpdater struct{}
+
+// RealUpdater returns the pkg.Updater SelfUpdate should use outside of
+// tests.
+func RealUpdater() pkg.Updater {
+	return httpUpdater{}
+}
+
+func (httpUpdater) LatestManifest(channel string) (pkg.ReleaseManifest, error) {
+	body, err := fetchURLBytes(selfUpdateManifestURL(channel))
+	if err != nil {
+		return pkg.ReleaseManifest{}, fmt.Errorf("failed to fetch release manifest: %w", err)
+	}
+
+	var manifest pkg.ReleaseManifest
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return pkg.ReleaseManifest{}, fmt.Errorf("failed to parse release manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+func (httpUpdater) DownloadBinary(asset pkg.ReleaseAsset) ([]byte, error) {
+	return fetchURLBytes(asset.URL)
+}
+
+// selfUpdatePublicKeyB64 is the base64-encoded ed25519 public key
+// node-spark trusts to sign release binaries. Like nodeReleaseKeyringASC in
+// verify.go, this ships empty on purpose: the real key has to be copied
+// verbatim from node-spark's release signing setup, not reconstructed from
+// memory, since a single wrong byte would make verification either accept
+// forged binaries or reject every real one. A maintainer should set this
+// before shipping self-update as a supported command.
+const selfUpdatePublicKeyB64 = ``
+
+// SelfUpdate checks channel's release manifest for a version newer than
+// currentVersion (the build-time version from cmd.version), and if found,
+// downloads, verifies (SHA-256 checksum + ed25519 signature), and atomically
+// installs it in place of the running executable. The replaced binary is
+// kept at "<executable>.old" for RollbackSelfUpdate. updater is the release
+// source to use; production callers should pass RealUpdater(). It errors out
+// immediately, before touching the network, if no signing key is bundled
+// (see selfUpdatePublicKeyB64) - a command that can only ever fail its own
+// verification step is surfaced as unavailable rather than attempted.
+func SelfUpdate(currentVersion, channel string, updater pkg.Updater) error {
+	if channel == "" {
+		channel = "stable"
+	}
+
+	if strings.TrimSpace(selfUpdatePublicKeyB64) == "" {
+		return fmt.Errorf("self-update is not available in this build: no release signing key is bundled")
+	}
+
+	manifest, err := updater.LatestManifest(channel)
+	if err != nil {
+		return err
+	}
+
+	if compareVersions(manifest.Version, currentVersion) <= 0 {
+		fmt.Printf("node-spark %s is already up to date (latest %s release: %s).\n", currentVersion, channel, manifest.Version)
+		return nil
+	}
+
+	platform := runtime.GOOS + "-" + runtime.GOARCH
+	asset, ok := manifest.Assets[platform]
+	if !ok {
+		return fmt.Errorf("release %s has no build published for %s", manifest.Version, platform)
+	}
+
+	fmt.Printf("Downloading node-spark %s for %s...\n", manifest.Version, platform)
+	data, err := updater.DownloadBinary(asset)
+	if err != nil {
+		return fmt.Errorf("failed to download release binary: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	actual := hex.EncodeToString(sum[:])
+	if !strings.EqualFold(actual, asset.SHA256) {
+		return &ChecksumError{Filename: platform, Expected: asset.SHA256, Actual: actual}
+	}
+
+	if err := verifySelfUpdateSignature(data, asset.Signature); err != nil {
+		return err
+	}
+	fmt.Println("Checksum and signature verified.")
+
+	currentPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the running executable: %w", err)
+	}
+	currentPath, err = filepath.EvalSymlinks(currentPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve the running executable path: %w", err)
+	}
+
+	newPath := currentPath + ".new"
+	if err := os.WriteFile(newPath, data, 0755); err != nil {
+		return fmt.Errorf("failed to write the downloaded binary: %w", err)
+	}
+
+	if err := swapExecutable(newPath, currentPath); err != nil {
+		os.Remove(newPath)
+		return err
+	}
+
+	fmt.Printf("Updated node-spark %s -> %s.\n", currentVersion, manifest.Version)
+	fmt.Printf("The previous binary was kept at %s; run 'nsk update --rollback' to restore it.\n", currentPath+".old")
+	return nil
+}
+
+// verifySelfUpdateSignature checks that signatureB64 is a valid base64
+// detached ed25519 signature over data, made by selfUpdatePublicKeyB64.
+func verifySelfUpdateSignature(data []byte, signatureB64 string) error {
+	if strings.TrimSpace(selfUpdatePublicKeyB64) == "" {
+		return fmt.Errorf("no release signing key bundled; refusing to install an unverified update")
+	}
+
+	pubKey, err := base64.StdEncoding.DecodeString(selfUpdatePublicKeyB64)
+	if err != nil || len(pubKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("bundled release signing key is malformed")
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(signatureB64)
+	if err != nil {
+		return fmt.Errorf("release signature is not valid base64: %w", err)
+	}
+
+	if !ed25519.Verify(ed25519.PublicKey(pubKey), data, sig) {
+		return fmt.Errorf("release binary signature does not match the bundled signing key")
+	}
+
+	return nil
+}
+
+// swapExecutable atomically replaces currentPath with newPath, keeping
+// currentPath+".old" as a backup for RollbackSelfUpdate. Both platforms back
+// up the running binary via rename before moving the new one into place;
+// Windows needs this specifically to work around its running-executable
+// lock (a rename straight onto currentPath would fail while it's the
+// process image), while on POSIX os.Rename could replace currentPath
+// directly even while it's running, but renaming it aside first gets the
+// same rollback backup for free.
+func swapExecutable(newPath, currentPath string) error {
+	backupPath := currentPath + ".old"
+	os.Remove(backupPath) // best-effort: a stale .old from a prior update is fine to clobber
+
+	if err := os.Rename(currentPath, backupPath); err != nil {
+		return fmt.Errorf("failed to move the running executable aside: %w", err)
+	}
+	if err := os.Rename(newPath, currentPath); err != nil {
+		os.Rename(backupPath, currentPath) // best-effort restore
+		return fmt.Errorf("failed to install the new executable: %w", err)
+	}
+	return nil
+}
+
+// RollbackSelfUpdate restores the executable that the most recent
+// SelfUpdate replaced, moving currentPath+".old" back over currentPath. It
+// fails if no backup is present.
+func RollbackSelfUpdate() error {
+	currentPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the running executable: %w", err)
+	}
+	currentPath, err = filepath.EvalSymlinks(currentPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve the running executable path: %w", err)
+	}
+
+	backupPath := currentPath + ".old"
+	if _, err := os.Stat(backupPath); err != nil {
+		return fmt.Errorf("no previous binary found at %s to roll back to", backupPath)
+	}
+
+	asidePath := currentPath + ".rollback-tmp"
+	if err := os.Rename(currentPath, asidePath); err != nil {
+		return fmt.Errorf("failed to move the current executable aside: %w", err)
+	}
+	if err := os.Rename(backupPath, currentPath); err != nil {
+		os.Rename(asidePath, currentPath) // best-effort restore
+		return fmt.Errorf("failed to restore the previous executable: %w", err)
+	}
+	os.Remove(asidePath)
+
+	fmt.Printf("Rolled back node-spark to the previous binary at %s.\n", currentPath)
+	return nil
+}
@@ -0,0 +1,70 @@
+// internal/shellinit.go
+
+package internal
+
+import "fmt"
+
+// bashHook and zshHook both use bash-compatible syntax; they trap every
+// prompt command and re-run "nsk resolve --silent" whenever the working
+// directory has changed since the last prompt, mirroring nvm's/nvm-windows's
+// cd hooks. "resolve --silent" is the same resolution "nsk auto" runs
+// interactively, just without the progress output.
+const bashHook = `# node-spark shell hook
+_node_spark_auto() {
+  if [ "$PWD" != "$_NODE_SPARK_LAST_DIR" ]; then
+    _NODE_SPARK_LAST_DIR="$PWD"
+    nsk resolve --silent >/dev/null 2>&1
+  fi
+}
+if [[ ";${PROMPT_COMMAND:-};" != *";_node_spark_auto;"* ]]; then
+  PROMPT_COMMAND="_node_spark_auto;${PROMPT_COMMAND:-}"
+fi
+`
+
+const zshHook = `# node-spark shell hook
+_node_spark_auto() {
+  if [ "$PWD" != "$_NODE_SPARK_LAST_DIR" ]; then
+    _NODE_SPARK_LAST_DIR="$PWD"
+    nsk resolve --silent >/dev/null 2>&1
+  fi
+}
+autoload -U add-zsh-hook
+add-zsh-hook chpwd _node_spark_auto
+_node_spark_auto
+`
+
+const fishHook = `# node-spark shell hook
+function __node_spark_auto --on-variable PWD
+  nsk resolve --silent >/dev/null 2>&1
+end
+`
+
+const powershellHook = `# node-spark shell hook
+$global:NodeSparkLastDir = $null
+$ExecutionContext.InvokeCommand.LocationChangedAction = {
+  if ($global:NodeSparkLastDir -ne $PWD.Path) {
+    $global:NodeSparkLastDir = $PWD.Path
+    nsk resolve --silent | Out-Null
+  }
+}
+`
+
+// GenerateShellHook returns the shell-init script for shell ("bash", "zsh",
+// "fish", or "powershell"), which the caller is expected to eval or source
+// from their shell's startup file, e.g.:
+//
+//	eval "$(nsk shell-init bash)"
+func GenerateShellHook(shell string) (string, error) {
+	switch shell {
+	case "bash":
+		return bashHook, nil
+	case "zsh":
+		return zshHook, nil
+	case "fish":
+		return fishHook, nil
+	case "powershell":
+		return powershellHook, nil
+	default:
+		return "", fmt.Errorf("unsupported shell %q (supported: bash, zsh, fish, powershell)", shell)
+	}
+}
@@ -0,0 +1,16 @@
+// Package shimassets embeds the compiled Windows shim launcher used by
+// internal/shims.go for global installs. The launcher's source lives in
+// internal/shimsrc and is rebuilt with:
+//
+//	GOOS=windows GOARCH=amd64 go build -o internal/shimassets/shim.exe ./internal/shimsrc
+package shimassets
+
+import _ "embed"
+
+// ShimExe is the compiled shim launcher: at startup it reads a ".shim" text
+// file next to its own path (e.g. node.exe reads node.shim) for a "path="
+// target and optional "args=", then execs the target with those args
+// followed by its own argv, forwarding stdio and the exit code.
+//
+//go:embed shim.exe
+var ShimExe []byte
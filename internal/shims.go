@@ -0,0 +1,172 @@
+// internal/shims.go
+
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/s42yt/node-spark/internal/shimassets"
+)
+
+// nodeShimBinaries are the logical shim names UseVersion retargets on every
+// version switch; nsk's own shim (written once by InstallGlobal) isn't
+// among them since nsk itself never moves.
+var nodeShimBinaries = []string{"node", "npm", "npx"}
+
+// shimDir returns the single directory node-spark's PATH shims live in -
+// added to PATH exactly once, by InstallGlobal. Every tool node-spark
+// manages (node, npm, npx, and nsk itself) gets a shim here, so switching
+// Node versions only ever rewrites the node/npm/npx shims in place; PATH
+// itself never needs touching again (see writeShim, retargetNodeShims).
+func shimDir() (string, error) {
+	if runtime.GOOS == "windows" {
+		localAppData := os.Getenv("LOCALAPPDATA")
+		if localAppData == "" {
+			return "", fmt.Errorf("LOCALAPPDATA environment variable not set")
+		}
+		return filepath.Join(localAppData, "node-spark", "shims"), nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".node-spark", "shims"), nil
+}
+
+// shimFilename returns the filename a shim for a logical binary name
+// ("node", "npm", "npx", "nsk") is written as on this OS. On Windows every
+// shim is the compiled shimassets.ShimExe launcher, so it must be named
+// ".exe" regardless of binary - a ".cmd" file is run by cmd.exe as a batch
+// script, not executed as the PE image writeShim puts in it.
+func shimFilename(binary string) string {
+	if runtime.GOOS != "windows" {
+		return binary
+	}
+	return binary + ".exe"
+}
+
+// writeShim writes (or overwrites) the shim for binary so that running it
+// execs target, with args prepended to whatever arguments the caller
+// invokes it with. On Windows this is shimassets.ShimExe plus the ".shim"
+// config file it reads at startup (the Scoop/kiennq model); on Unix it's a
+// small POSIX shell script, since exec there inherits argv/stdio without
+// needing a compiled launcher.
+func writeShim(dir, binary, target string, args ...string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create shim directory: %w", err)
+	}
+
+	shimPath := filepath.Join(dir, shimFilename(binary))
+
+	if runtime.GOOS == "windows" {
+		if err := os.WriteFile(shimPath, shimassets.ShimExe, 0755); err != nil {
+			return fmt.Errorf("failed to write shim for %s: %w", binary, err)
+		}
+		configPath := strings.TrimSuffix(shimPath, filepath.Ext(shimPath)) + ".shim"
+		config := fmt.Sprintf("path=%s\nargs=%s\n", target, strings.Join(args, " "))
+		if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+			return fmt.Errorf("failed to write shim config for %s: %w", binary, err)
+		}
+		return nil
+	}
+
+	var script strings.Builder
+	script.WriteString("#!/bin/sh\n")
+	script.WriteString("exec " + shellQuote(target))
+	for _, a := range args {
+		script.WriteString(" " + shellQuote(a))
+	}
+	script.WriteString(` "$@"` + "\n")
+	if err := os.WriteFile(shimPath, []byte(script.String()), 0755); err != nil {
+		return fmt.Errorf("failed to write shim for %s: %w", binary, err)
+	}
+	return nil
+}
+
+// removeShim deletes binary's shim (and, on Windows, its ".shim" config)
+// from dir, if present.
+func removeShim(dir, binary string) error {
+	shimPath := filepath.Join(dir, shimFilename(binary))
+	if err := os.Remove(shimPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if runtime.GOOS == "windows" {
+		configPath := strings.TrimSuffix(shimPath, filepath.Ext(shimPath)) + ".shim"
+		if err := os.Remove(configPath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// shellQuote wraps s in single quotes for safe use in a generated shim
+// script, escaping any embedded single quotes.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// writeNskShim installs the fixed nsk shim pointing at nskPath, the real
+// installed nsk executable. Unlike the node/npm/npx shims, it's written
+// once by InstallGlobal and never retargeted.
+func writeNskShim(dir, nskPath string) error {
+	return writeShim(dir, "nsk", nskPath)
+}
+
+// retargetNodeShims rewrites the node/npm/npx shims to point at the
+// binaries inside versionPath - the only filesystem work switching a Node
+// version needs to do now that PATH is set up once, by InstallGlobal.
+// Binaries a given Node build doesn't ship (npm.cmd on some archives, for
+// instance) are left with whatever shim they already had.
+func retargetNodeShims(versionPath string) error {
+	dir, err := shimDir()
+	if err != nil {
+		return err
+	}
+
+	nodePath := nodeBinaryPath(versionPath)
+	binDir := filepath.Dir(nodePath)
+
+	npmName, npxName := "npm", "npx"
+	if runtime.GOOS == "windows" {
+		npmName, npxName = "npm.cmd", "npx.cmd"
+	}
+
+	targets := map[string]string{
+		"node": nodePath,
+		"npm":  filepath.Join(binDir, npmName),
+		"npx":  filepath.Join(binDir, npxName),
+	}
+
+	for _, binary := range nodeShimBinaries {
+		target := targets[binary]
+		if _, err := os.Stat(target); err != nil {
+			continue
+		}
+		if err := writeShim(dir, binary, target); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeNodeShims deletes the node/npm/npx shims so those commands fall
+// through to whatever the rest of PATH provides - used by the "system"
+// alias to hand control back to a system-installed Node.
+func removeNodeShims() error {
+	dir, err := shimDir()
+	if err != nil {
+		return err
+	}
+	for _, binary := range nodeShimBinaries {
+		if err := removeShim(dir, binary); err != nil {
+			return fmt.Errorf("failed to remove the %s shim: %w", binary, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	self, err := os.Executable()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "shim: could not determine own path:", err)
+		os.Exit(1)
+	}
+
+	shimFile := strings.TrimSuffix(self, filepath.Ext(self)) + ".shim"
+	path, extraArgs, err := readShim(shimFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "shim:", err)
+		os.Exit(1)
+	}
+
+	args := append(extraArgs, os.Args[1:]...)
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			os.Exit(exitErr.ExitCode())
+		}
+		fmt.Fprintln(os.Stderr, "shim:", err)
+		os.Exit(1)
+	}
+}
+
+func readShim(shimFile string) (path string, args []string, err error) {
+	f, openErr := os.Open(shimFile)
+	if openErr != nil {
+		return "", nil, fmt.Errorf("no shim config at %s: %w", shimFile, openErr)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "path="):
+			path = strings.TrimPrefix(line, "path=")
+		case strings.HasPrefix(line, "args="):
+			rest := strings.TrimPrefix(line, "args=")
+			if rest != "" {
+				args = strings.Fields(rest)
+			}
+		}
+	}
+	if path == "" {
+		return "", nil, fmt.Errorf("%s has no path= entry", shimFile)
+	}
+	return path, args, scanner.Err()
+}
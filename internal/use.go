@@ -19,16 +19,65 @@ import (
 	"github.com/s42yt/node-spark/pkg"
 )
 
-// UseVersion switches the active Node.js version by creating appropriate symlinks
-// or, on Windows, by modifying PATH-related registry entries and creating shims.
-func UseVersion(version string, config *pkg.Config) error {
-	// Check if the version is installed
-	versionPath := filepath.Join(pkg.GetInstallPath(config), version)
-	if _, err := os.Stat(versionPath); os.IsNotExist(err) {
-		return fmt.Errorf("version %s is not installed", version)
+// quietOutput, toggled by SetQuiet, suppresses the routine progress messages
+// UseVersion/SetActiveVersion print. It exists for the auto-switch shell
+// hook (see autoswitch.go / the "resolve --silent" command), which re-runs
+// on every prompt and would otherwise spam stdout on each cd.
+var quietOutput bool
+
+// SetQuiet toggles quiet mode for the rest of the process.
+func SetQuiet(q bool) {
+	quietOutput = q
+}
+
+// printf writes a routine progress message unless quiet mode is on. Actual
+// warnings are printed with fmt.Printf directly, since those indicate a real
+// problem and should surface even from the silent hook.
+func printf(format string, a ...interface{}) {
+	if quietOutput {
+		return
+	}
+	fmt.Printf(format, a...)
+}
+
+// UseVersion switches the active Node.js version. versionSpec is resolved
+// through ResolveVersionSpec first, so it accepts concrete versions, semver
+// ranges, `lts`/`lts/<codename>`, `latest`/`node`, user-defined
+// config.Aliases entries, and `system` (see SystemAlias) - this is the
+// single chokepoint both the CLI and the TUI switch versions through, so
+// they resolve aliases identically. arch optionally pins which installed
+// architecture to activate (e.g. "arm64" when both x64 and arm64 builds of
+// versionSpec are installed side-by-side); an empty arch auto-selects, with
+// a runnability fallback - see resolveVersionArchPath in arch.go. When
+// global is true, the switch is persistent system-wide: it rewrites the
+// node/npm/npx shims in the shared shim directory (see shims.go) to point
+// at the new version, so every terminal picks it up without any further
+// PATH change. When global is false, it only updates config.ActiveVersion
+// bookkeeping; the caller is expected to
+// separately print a per-shell activation script via
+// GenerateActivationScript (see the "env" command) so PATH changes stay
+// scoped to that one terminal session.
+func UseVersion(versionSpec, arch string, config *pkg.Config, global bool) error {
+	version, err := ResolveVersionSpec(versionSpec, config, false)
+	if err != nil {
+		return err
 	}
 
-	fmt.Printf("Switching to Node.js version %s...\n", version)
+	if version == SystemAlias {
+		return useSystemVersion(config, global)
+	}
+
+	resolvedArch, versionPath, err := resolveVersionArchPath(config, version, arch)
+	if err != nil {
+		return err
+	}
+
+	if !global {
+		config.ActiveVersion = version
+		return nil
+	}
+
+	printf("Switching to Node.js version %s (%s)...\n", version, resolvedArch)
 
 	// The current implementation approach differs by OS
 	if runtime.GOOS == "windows" {
@@ -38,95 +87,114 @@ func UseVersion(version string, config *pkg.Config) error {
 	return useVersionPosix(version, versionPath, config)
 }
 
-// useVersionWindows implements version switching for Windows
-// by creating batch script shims in a central location
-func useVersionWindows(version, versionPath string, config *pkg.Config) error {
-	// Base directory for node-spark
+// useSystemVersion implements the "system" alias: it clears
+// config.ActiveVersion and, when global, removes node-spark's node/npm/npx
+// shims entirely so those commands fall through to whatever the OS itself
+// provides on the rest of PATH.
+func useSystemVersion(config *pkg.Config, global bool) error {
+	config.ActiveVersion = ""
+
+	if !global {
+		return nil
+	}
+
+	if err := removeNodeShims(); err != nil {
+		return err
+	}
+
+	printf("node-spark deactivated; 'node' now resolves to your system PATH.\n")
+	return nil
+}
+
+// currentLinkPath returns the path of the shared "current" symlink/junction
+// that always points at the active version, e.g. ~/.node-spark/current.
+func currentLinkPath() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		return fmt.Errorf("could not get home directory: %w", err)
+		return "", fmt.Errorf("could not get home directory: %w", err)
 	}
+	return filepath.Join(homeDir, ".node-spark", "current"), nil
+}
 
-	nodeBinPath := filepath.Join(versionPath, "bin")
-	if _, err := os.Stat(nodeBinPath); os.IsNotExist(err) {
-		// On Windows, executables might be directly in the version folder
-		nodeBinPath = versionPath
+// atomicSymlink points linkPath at target by creating a temporary symlink
+// next to it and renaming it into place, so switches never leave linkPath
+// missing or half-written.
+func atomicSymlink(target, linkPath string) error {
+	if err := os.MkdirAll(filepath.Dir(linkPath), 0755); err != nil {
+		return fmt.Errorf("failed to create parent directory for symlink: %w", err)
 	}
 
-	// Create shims directory
-	shimDir := filepath.Join(homeDir, ".node-spark", "shims")
+	tmpLink := linkPath + ".tmp"
+	os.Remove(tmpLink) // Clean up any leftover temp link from a previous failed swap
 
-	// Create the shim files using our Windows helper
-	if err := CreateWindowsShims(nodeBinPath, shimDir); err != nil {
-		return err
+	if err := os.Symlink(target, tmpLink); err != nil {
+		return fmt.Errorf("failed to create symlink: %w", err)
 	}
 
-	// Update the PATH environment variable
-	if err := UpdateWindowsPath(shimDir); err != nil {
-		fmt.Printf("Warning: Could not fully update PATH environment: %v\n", err)
-		// Continue anyway - not fatal
+	if err := os.Rename(tmpLink, linkPath); err != nil {
+		os.Remove(tmpLink)
+		return fmt.Errorf("failed to atomically swap symlink: %w", err)
 	}
 
-	// Create activation script for immediate use
-	activateScript, err := CreateWindowsActivationScript(shimDir, version)
-	if err != nil {
-		fmt.Printf("Warning: %v\n", err)
-		// Continue anyway - not fatal
+	return nil
+}
+
+// useVersionWindows implements version switching for Windows by rewriting
+// the node/npm/npx shims in the shared shim directory (see shims.go) to
+// point at versionPath. This replaced an earlier directory-junction
+// approach that needed a junction-creation fallback; shims need no such
+// fallback since writing a shim is just a couple of file writes.
+func useVersionWindows(version, versionPath string, config *pkg.Config) error {
+	if err := retargetNodeShims(versionPath); err != nil {
+		return fmt.Errorf("failed to switch Node.js version: %w", err)
 	}
 
-	// Set or update the ActiveVersion in config
 	config.ActiveVersion = version
 
-	// Verify the node executable is compatible with the system
-	nodePath := filepath.Join(shimDir, "node.exe")
-	if !IsProperArchForSystem(nodePath) {
+	if !IsProperArchForSystem(nodeBinaryPath(versionPath)) {
 		fmt.Printf("Warning: The installed Node.js binary may not be compatible with your system.\n")
 		fmt.Printf("You might need to install a different architecture version.\n")
 	}
 
-	fmt.Printf("Created shims in %s\n", shimDir)
-	fmt.Printf("Node.js %s should now be available in new terminal windows.\n", version)
-	fmt.Printf("For immediate use in your current terminal, run: . %s\n", activateScript)
+	printf("Switched the active version shims to %s\n", versionPath)
 
 	return nil
 }
 
-// useVersionPosix implements version switching for Unix-like systems
-// by creating symlinks in a central location
-func useVersionPosix(version, versionPath string, config *pkg.Config) error {
-	// Base directory for node-spark
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("could not get home directory: %w", err)
+// atomicJunction retargets a Windows directory junction at linkPath to point
+// at target, replacing any existing junction. mklink /J requires no special
+// privileges on NTFS, unlike symbolic links.
+func atomicJunction(target, linkPath string) error {
+	if err := os.MkdirAll(filepath.Dir(linkPath), 0755); err != nil {
+		return fmt.Errorf("failed to create parent directory for junction: %w", err)
 	}
 
-	// Create the symlink directory
-	symlinkDir := filepath.Join(homeDir, ".node-spark", "current")
-	if err := os.MkdirAll(filepath.Dir(symlinkDir), 0755); err != nil {
-		return fmt.Errorf("failed to create parent directory for symlink: %w", err)
+	if _, err := os.Lstat(linkPath); err == nil {
+		if err := exec.Command("cmd", "/c", "rmdir", linkPath).Run(); err != nil {
+			return fmt.Errorf("failed to remove existing junction: %w", err)
+		}
 	}
 
-	// Remove existing symlink if it exists
-	if _, err := os.Lstat(symlinkDir); err == nil {
-		if err := os.Remove(symlinkDir); err != nil {
-			return fmt.Errorf("failed to remove existing symlink: %w", err)
-		}
+	if out, err := exec.Command("cmd", "/c", "mklink", "/J", linkPath, target).CombinedOutput(); err != nil {
+		return fmt.Errorf("mklink /J failed: %w (%s)", err, strings.TrimSpace(string(out)))
 	}
 
-	// Create new symlink
-	if err := os.Symlink(versionPath, symlinkDir); err != nil {
-		return fmt.Errorf("failed to create symlink: %w", err)
+	return nil
+}
+
+// useVersionPosix implements version switching for Unix-like systems by
+// rewriting the node/npm/npx shims in the shared shim directory (see
+// shims.go) to point at versionPath. This replaced an earlier
+// symlink-retargeting approach; shims need no separate "current" link at
+// all, since each one execs its target directly.
+func useVersionPosix(version, versionPath string, config *pkg.Config) error {
+	if err := retargetNodeShims(versionPath); err != nil {
+		return err
 	}
 
-	// Set the ActiveVersion in config
 	config.ActiveVersion = version
 
-	fmt.Printf("Successfully switched to Node.js %s\n", version)
-	fmt.Printf("Make sure %s/bin is in your PATH\n", symlinkDir)
-
-	// Suggest adding to shell config if not already there
-	fmt.Println("\nTo ensure the Node.js version persists in new terminal sessions, add this to your shell config file:")
-	fmt.Printf("export PATH=\"%s/bin:$PATH\"\n", symlinkDir)
+	printf("Successfully switched to Node.js %s\n", version)
 
 	return nil
 }
@@ -183,24 +251,21 @@ func ListAvailableNodeVersions() ([]string, error) {
 	return result, nil
 }
 
-// SetActiveVersion updates the configuration to mark a version as active.
-// This currently only updates the config file. Actual PATH modification
-// or symlinking needs to be handled separately (e.g., by user's shell profile
-// sourcing a script generated by node-spark, or by direct symlinking if feasible).
-func SetActiveVersion(version string, config *pkg.Config) error {
-	// Verify the version is actually installed
-	versionPath := filepath.Join(pkg.GetInstallPath(config), version)
-	if _, err := os.Stat(versionPath); os.IsNotExist(err) {
-		return fmt.Errorf("version %s is not installed", version)
-	}
-
-	// Now use our implementation that actually creates symlinks or shims
-	if err := UseVersion(version, config); err != nil {
+// SetActiveVersion resolves versionSpec (see UseVersion) and marks it active
+// in config, switching it persistently system-wide via UseVersion when
+// global is true. See UseVersion for what global does and doesn't touch, and
+// what an empty arch means.
+func SetActiveVersion(versionSpec, arch string, config *pkg.Config, global bool) error {
+	if err := UseVersion(versionSpec, arch, config, global); err != nil {
 		return err
 	}
 
-	config.ActiveVersion = version
-	fmt.Printf("Set active Node.js version to %s\n", version)
+	if global {
+		if config.ActiveVersion == "" {
+			return nil
+		}
+		printf("Set active Node.js version to %s\n", config.ActiveVersion)
+	}
 	return nil
 }
 
@@ -217,8 +282,18 @@ func ListInstalledVersions(config *pkg.Config) ([]string, error) {
 	return config.InstalledVersions, nil
 }
 
-// UninstallVersion removes an installed Node.js version
-func UninstallVersion(version string, config *pkg.Config) error {
+// UninstallVersion removes an installed Node.js version. versionSpec is
+// resolved through ResolveVersionSpec first, same as UseVersion, so aliases
+// like "lts/hydrogen" or a user-defined config.Aliases name work here too.
+func UninstallVersion(versionSpec string, config *pkg.Config) error {
+	version, err := ResolveVersionSpec(versionSpec, config, false)
+	if err != nil {
+		return err
+	}
+	if version == SystemAlias {
+		return fmt.Errorf("%q does not refer to a managed Node.js version", SystemAlias)
+	}
+
 	// Check if version is currently active
 	if config.ActiveVersion == version {
 		return fmt.Errorf("cannot uninstall the currently active version; switch to another version first")
@@ -230,10 +305,11 @@ func UninstallVersion(version string, config *pkg.Config) error {
 		return fmt.Errorf("version %s is not installed", version)
 	}
 
-	// Remove the version directory
+	// Remove the version directory, including every installed architecture
 	if err := os.RemoveAll(versionPath); err != nil {
 		return fmt.Errorf("failed to remove version directory: %w", err)
 	}
+	delete(config.InstalledArches, version)
 
 	// Update the config to remove this version
 	for i, v := range config.InstalledVersions {
@@ -265,17 +341,38 @@ type TUIModel struct {
 	input         textinput.Model
 }
 
-// item represents an item in the TUI list
+// item represents an item in the TUI list. version/archs/archIdx are only
+// populated for real installed-version rows (the placeholder "no versions"
+// item leaves them zero); archs holds every architecture installed for
+// version, and archIdx selects which one Title() displays and "u"/"x" act
+// on, cycled by the "a" key when len(archs) > 1.
 type item struct {
 	title       string
 	description string
 	isActive    bool
+	version     string
+	archs       []string
+	archIdx     int
 }
 
-func (i item) Title() string       { return i.title }
+func (i item) Title() string {
+	if i.version == "" || len(i.archs) == 0 {
+		return i.title
+	}
+	return fmt.Sprintf("%s (%s)", i.version, i.archs[i.archIdx])
+}
 func (i item) Description() string { return i.description }
 func (i item) FilterValue() string { return i.title }
 
+// selectedArch returns the architecture the item's current archIdx points
+// at, or "" if the item has none recorded (e.g. the placeholder item).
+func (i item) selectedArch() string {
+	if len(i.archs) == 0 {
+		return ""
+	}
+	return i.archs[i.archIdx]
+}
+
 // initTUI initializes the TUI model
 func InitTUI(config *pkg.Config) tea.Model {
 	// Create spinner
@@ -370,7 +467,19 @@ func (m TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if isActive {
 				desc = "Currently active version"
 			}
-			items[i] = item{title: v, description: desc, isActive: isActive}
+			if badges := AliasBadgesFor(v, m.config); len(badges) > 0 {
+				badgeText := "[" + strings.Join(badges, ", ") + "]"
+				if desc != "" {
+					desc += " " + badgeText
+				} else {
+					desc = badgeText
+				}
+			}
+			archs := installedArches(m.config, v)
+			if len(archs) == 0 {
+				archs = []string{defaultNodeArch()}
+			}
+			items[i] = item{title: v, description: desc, isActive: isActive, version: v, archs: archs}
 		}
 
 		delegate := list.NewDefaultDelegate()
@@ -388,6 +497,10 @@ func (m TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					key.WithKeys("x"),
 					key.WithHelp("x", "uninstall version"),
 				),
+				key.NewBinding(
+					key.WithKeys("a"),
+					key.WithHelp("a", "toggle architecture"),
+				),
 				key.NewBinding(
 					key.WithKeys("i"),
 					key.WithHelp("i", "install new version"),
@@ -432,13 +545,13 @@ func (m TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Handle custom key presses for list items
 		if key, ok := msg.(tea.KeyMsg); ok {
 			switch key.String() {
-			case "u": // Use the selected version
+			case "u": // Use the selected version (at its currently toggled architecture)
 				if len(m.list.Items()) > 0 && m.list.SelectedItem() != nil {
 					selected := m.list.SelectedItem().(item)
 					// Don't try to "use" our placeholder message
 					if selected.title != "No Node.js versions installed" {
 						m.loading = true
-						cmds = append(cmds, useVersionCmd(selected.title, m.config)) // Use the command wrapper
+						cmds = append(cmds, useVersionCmd(selected.title, selected.selectedArch(), m.config))
 					}
 				}
 			case "x": // Uninstall the selected version
@@ -450,6 +563,14 @@ func (m TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						cmds = append(cmds, uninstallVersion(selected.title, m.config))
 					}
 				}
+			case "a": // Cycle which installed architecture this row displays/acts on
+				if len(m.list.Items()) > 0 && m.list.SelectedItem() != nil {
+					selected := m.list.SelectedItem().(item)
+					if len(selected.archs) > 1 {
+						selected.archIdx = (selected.archIdx + 1) % len(selected.archs)
+						cmds = append(cmds, m.list.SetItem(m.list.Index(), selected))
+					}
+				}
 			case "i": // Install a new version
 				m.state = "install"
 				m.input.Focus()
@@ -534,18 +655,18 @@ func loadInstalledVersions(config *pkg.Config) tea.Cmd {
 
 func installVersion(version string, config *pkg.Config) tea.Cmd {
 	return func() tea.Msg {
-		// We need to import the installation function from install.go
-		// Assuming InstallNodeVersion exists in another package or needs to be defined/imported
-		// For now, let's assume it's available. If not, that's a separate issue.
-		err := InstallNodeVersion(version, config) // Placeholder if not defined
+		_, err := InstallNodeVersion(version, config, false, "", true)
 		return installedVersionMsg{version: version, err: err}
 	}
 }
 
-// useVersionCmd wraps the UseVersion logic in a tea.Cmd
-func useVersionCmd(version string, config *pkg.Config) tea.Cmd {
+// useVersionCmd wraps the UseVersion logic in a tea.Cmd. The TUI always
+// switches globally since it has no concept of "the current shell session".
+// arch selects which installed architecture to activate, honoring the row's
+// current "a"-toggled selection; "" lets UseVersion pick automatically.
+func useVersionCmd(version, arch string, config *pkg.Config) tea.Cmd {
 	return func() tea.Msg {
-		err := UseVersion(version, config)
+		err := UseVersion(version, arch, config, true)
 		return versionActivatedMsg{version: version, err: err}
 	}
 }
@@ -0,0 +1,259 @@
+// internal/verify.go
+
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/s42yt/node-spark/pkg"
+)
+
+// ChecksumError indicates that a downloaded archive's SHA-256 digest did not
+// match the value published in the release's SHASUMS256.txt manifest.
+type ChecksumError struct {
+	Filename string
+	Expected string
+	Actual   string
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("checksum mismatch for %s: expected %s, got %s", e.Filename, e.Expected, e.Actual)
+}
+
+// fetchURLBytes downloads url and returns its body, failing on any non-200
+// status. It's the shared plumbing behind fetchShasumsRaw and fetchSignature.
+func fetchURLBytes(url string) ([]byte, error) {
+	settings, err := EffectiveSettings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load settings: %w", err)
+	}
+
+	client := NewHTTPClient(settings)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", url, err)
+	}
+	req.Header.Set("User-Agent", "node-spark/1.0")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: status code %d", url, resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// fetchShasumsRaw downloads the SHASUMS256.txt manifest for versionStr,
+// returning both its raw bytes (needed to verify the detached OpenPGP
+// signature over the exact manifest text) and the parsed filename -> expected
+// SHA-256 hex digest map.
+func fetchShasumsRaw(versionStr string) (raw []byte, sums map[string]string, err error) {
+	settings, err := EffectiveSettings()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load settings: %w", err)
+	}
+
+	raw, err = fetchURLBytes(ActiveDistribution().ChecksumURL(settings, versionStr))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch SHASUMS256.txt: %w", err)
+	}
+
+	sums = make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		sums[fields[1]] = fields[0]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read SHASUMS256.txt: %w", err)
+	}
+
+	return raw, sums, nil
+}
+
+// fetchShasums downloads and parses the SHASUMS256.txt manifest for the given
+// Node.js version, returning a map of filename -> expected SHA-256 hex digest.
+func fetchShasums(versionStr string) (map[string]string, error) {
+	_, sums, err := fetchShasumsRaw(versionStr)
+	return sums, err
+}
+
+// fetchSignature downloads the detached OpenPGP signature over versionStr's
+// SHASUMS256.txt manifest.
+func fetchSignature(versionStr string) ([]byte, error) {
+	settings, err := EffectiveSettings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load settings: %w", err)
+	}
+
+	sig, err := fetchURLBytes(ActiveDistribution().SignatureURL(settings, versionStr))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch SHASUMS256.txt.sig: %w", err)
+	}
+	return sig, nil
+}
+
+// VerifyArchiveChecksum validates that actualSHA256 matches the digest
+// published for filename in versionStr's SHASUMS256.txt manifest. This only
+// guards against corruption and mirror tampering of the archive relative to
+// the manifest; it does not establish that the manifest itself came from the
+// Node.js release team. See VerifySignature and VerifyArchive for that.
+func VerifyArchiveChecksum(versionStr, filename, actualSHA256 string) error {
+	sums, err := fetchShasums(versionStr)
+	if err != nil {
+		return err
+	}
+
+	expected, ok := sums[filename]
+	if !ok {
+		return fmt.Errorf("no checksum entry found for %s in SHASUMS256.txt", filename)
+	}
+
+	if !strings.EqualFold(expected, actualSHA256) {
+		return &ChecksumError{Filename: filename, Expected: expected, Actual: actualSHA256}
+	}
+
+	return nil
+}
+
+// SignatureError indicates that SHASUMS256.txt.sig did not verify as a valid
+// OpenPGP signature over SHASUMS256.txt from a key in the bundled Node.js
+// release-team keyring.
+type SignatureError struct {
+	Version string
+	Detail  string
+}
+
+func (e *SignatureError) Error() string {
+	return fmt.Sprintf("signature verification failed for %s's SHASUMS256.txt: %s", e.Version, e.Detail)
+}
+
+// nodeReleaseKeyringASC is the ASCII-armored OpenPGP public keyring node-spark
+// trusts to sign Node.js release manifests, analogous to nvm's NODE_CHECKSUM
+// key list. This placeholder ships empty on purpose: bundling real key
+// material here requires copying it verbatim from the Node.js project's
+// published release-keys list, not reconstructing it from memory, since a
+// single wrong byte would make "strict" verification either silently accept
+// forged manifests or silently reject every real one. A maintainer should
+// replace this constant with that keyring before shipping VerifyStrict as a
+// supported policy.
+//
+//	gpg --export --armor <release-team-key-ids...>
+const nodeReleaseKeyringASC = ``
+
+// VerifySignature verifies that signature is a valid detached OpenPGP
+// signature over raw, made by a key in the bundled Node.js release-team
+// keyring. It shells out to the system `gpg` binary rather than adding an
+// OpenPGP dependency to the module.
+func VerifySignature(raw, signature []byte) error {
+	if strings.TrimSpace(nodeReleaseKeyringASC) == "" {
+		return fmt.Errorf("no release-team keyring bundled; refusing to verify signatures")
+	}
+
+	if _, err := exec.LookPath("gpg"); err != nil {
+		return fmt.Errorf("gpg is required for signature verification but was not found on PATH: %w", err)
+	}
+
+	workDir, err := os.MkdirTemp("", "node-spark-verify-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory for signature verification: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	keyringPath := filepath.Join(workDir, "keyring.gpg")
+	manifestPath := filepath.Join(workDir, "SHASUMS256.txt")
+	sigPath := manifestPath + ".sig"
+
+	if err := os.WriteFile(manifestPath, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest for verification: %w", err)
+	}
+	if err := os.WriteFile(sigPath, signature, 0644); err != nil {
+		return fmt.Errorf("failed to write signature for verification: %w", err)
+	}
+
+	importCmd := exec.Command("gpg", "--batch", "--no-default-keyring", "--keyring", keyringPath, "--import")
+	importCmd.Stdin = strings.NewReader(nodeReleaseKeyringASC)
+	if out, err := importCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to import release-team keyring: %w (%s)", err, strings.TrimSpace(string(out)))
+	}
+
+	verifyCmd := exec.Command("gpg", "--batch", "--no-default-keyring", "--keyring", keyringPath, "--verify", sigPath, manifestPath)
+	out, err := verifyCmd.CombinedOutput()
+	if err != nil {
+		return &SignatureError{Detail: strings.TrimSpace(string(out))}
+	}
+
+	return nil
+}
+
+// VerifyArchive checks a downloaded archive according to policy, which
+// controls how much trust is required before InstallNodeVersion is allowed to
+// extract it:
+//
+//   - VerifyOff skips verification entirely.
+//   - VerifyChecksum (the default) checks actualSHA256 against SHASUMS256.txt,
+//     same as VerifyArchiveChecksum.
+//   - VerifyStrict additionally fetches SHASUMS256.txt.sig and verifies it
+//     against the bundled release-team keyring before trusting the manifest.
+//     It errors out immediately, before touching the network, if no keyring
+//     is bundled (see nodeReleaseKeyringASC) - a policy that always fails is
+//     surfaced as such rather than attempted.
+//
+// It returns a short human-readable summary of what was checked, suitable for
+// progress output and the TUI's installedVersionMsg.
+func VerifyArchive(policy pkg.VerificationPolicy, versionStr, filename, actualSHA256 string) (string, error) {
+	switch policy {
+	case pkg.VerifyOff:
+		return "skipped (verification policy: off)", nil
+
+	case pkg.VerifyStrict:
+		if strings.TrimSpace(nodeReleaseKeyringASC) == "" {
+			return "", fmt.Errorf("verification policy %q is not available in this build: no release-team keyring is bundled; use --verify checksum-only instead", pkg.VerifyStrict)
+		}
+		raw, sums, err := fetchShasumsRaw(versionStr)
+		if err != nil {
+			return "", err
+		}
+		sig, err := fetchSignature(versionStr)
+		if err != nil {
+			return "", err
+		}
+		if err := VerifySignature(raw, sig); err != nil {
+			if sigErr, ok := err.(*SignatureError); ok {
+				sigErr.Version = versionStr
+				return "", sigErr
+			}
+			return "", fmt.Errorf("signature verification failed for %s: %w", versionStr, err)
+		}
+		expected, ok := sums[filename]
+		if !ok {
+			return "", fmt.Errorf("no checksum entry found for %s in SHASUMS256.txt", filename)
+		}
+		if !strings.EqualFold(expected, actualSHA256) {
+			return "", &ChecksumError{Filename: filename, Expected: expected, Actual: actualSHA256}
+		}
+		return "checksum + signature verified", nil
+
+	default: // pkg.VerifyChecksum and "" both default to checksum-only
+		if err := VerifyArchiveChecksum(versionStr, filename, actualSHA256); err != nil {
+			return "", err
+		}
+		return "checksum verified", nil
+	}
+}
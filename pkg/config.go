@@ -11,6 +11,58 @@ type Config struct {
 	InstallPath       string   `json:"installPath"`
 	InstalledVersions []string `json:"installedVersions"`
 	ActiveVersion     string   `json:"activeVersion"` // Track the currently active version
+
+	// ActivePackageManagers tracks the active version of each independently
+	// managed package manager (npm, pnpm, yarn), keyed by tool name. These
+	// are installed under ~/.node-spark/pm/<tool>/<version>, decoupled from
+	// any particular Node.js install, and shimmed into the active Node
+	// version's bin directory in place of whatever npm/npx shipped with it.
+	// See internal/packagemanager.go.
+	ActivePackageManagers map[string]string `json:"activePackageManagers,omitempty"`
+
+	// Aliases maps user-defined names (e.g. "lts/hydrogen") to concrete
+	// versions, resolved by ResolveVersionSpec alongside the built-in
+	// "lts"/"lts/<codename>" aliases.
+	Aliases map[string]string `json:"aliases,omitempty"`
+
+	// Runtimes tracks installed/active versions for non-Node runtimes (deno,
+	// bun, iojs) managed through the Runtime registry, keyed by runtime name.
+	// Node itself keeps using InstalledVersions/ActiveVersion above.
+	Runtimes map[string]RuntimeState `json:"runtimes,omitempty"`
+
+	// VerificationPolicy controls how thoroughly InstallNodeVersion checks a
+	// downloaded archive before extracting it. Defaults to VerifyChecksum
+	// when empty. See internal/verify.go.
+	VerificationPolicy VerificationPolicy `json:"verificationPolicy,omitempty"`
+
+	// InstalledArches tracks which architectures are installed for each
+	// Node.js version, keyed by version ("20.11.0") with values like
+	// ["x64", "arm64"]. This lets x64 and arm64 (or x86) coexist
+	// side-by-side under installPath/<version>/<arch>, e.g. for Rosetta
+	// fallback on Apple Silicon or Windows-on-ARM. See internal/arch.go.
+	InstalledArches map[string][]string `json:"installedArches,omitempty"`
+}
+
+// VerificationPolicy selects how strictly a downloaded Node.js archive is
+// checked before it's extracted and activated.
+type VerificationPolicy string
+
+const (
+	// VerifyStrict requires both the SHA-256 checksum and the OpenPGP
+	// signature over SHASUMS256.txt to check out; installation fails if the
+	// signature can't be verified (missing gpg, unknown key, bad signature).
+	VerifyStrict VerificationPolicy = "strict"
+	// VerifyChecksum checks only the SHA-256 digest against SHASUMS256.txt,
+	// the historical node-spark behavior. This is the default.
+	VerifyChecksum VerificationPolicy = "checksum-only"
+	// VerifyOff skips all verification of downloaded archives.
+	VerifyOff VerificationPolicy = "off"
+)
+
+// RuntimeState tracks what's installed for a single non-Node runtime.
+type RuntimeState struct {
+	InstalledVersions []string `json:"installedVersions"`
+	ActiveVersion     string   `json:"activeVersion"`
 }
 
 // LoadConfig loads the configuration from a file.
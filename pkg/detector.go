@@ -0,0 +1,125 @@
+package pkg
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// VersionDetector inspects a single directory for a Node.js version spec (a
+// concrete version, a semver range, or an alias like "lts/hydrogen").
+// Detectors are tried in order by the auto-switch walk in
+// internal/autoswitch.go, and the first one to return a non-empty spec wins
+// - the same precedence nvm/nenv give .nvmrc over package.json.
+type VersionDetector interface {
+	// Name identifies the detector for diagnostics (e.g. ".nvmrc").
+	Name() string
+	// Detect looks for a version spec in dir, returning an empty spec and a
+	// nil error if dir has nothing for this detector to find.
+	Detect(dir string) (spec string, err error)
+}
+
+// NvmrcDetector reads a version spec from a ".nvmrc" file.
+type NvmrcDetector struct{}
+
+func (NvmrcDetector) Name() string { return ".nvmrc" }
+
+func (NvmrcDetector) Detect(dir string) (string, error) {
+	return readVersionFile(filepath.Join(dir, ".nvmrc"))
+}
+
+// NodeVersionDetector reads a version spec from a ".node-version" file.
+type NodeVersionDetector struct{}
+
+func (NodeVersionDetector) Name() string { return ".node-version" }
+
+func (NodeVersionDetector) Detect(dir string) (string, error) {
+	return readVersionFile(filepath.Join(dir, ".node-version"))
+}
+
+// PackageEnginesDetector reads the "engines.node" field of package.json.
+type PackageEnginesDetector struct{}
+
+func (PackageEnginesDetector) Name() string { return "package.json engines.node" }
+
+func (PackageEnginesDetector) Detect(dir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	var pj struct {
+		Engines struct {
+			Node string `json:"node"`
+		} `json:"engines"`
+	}
+	if err := json.Unmarshal(data, &pj); err != nil {
+		// A malformed package.json shouldn't block auto-switching; treat it
+		// the same as "no spec found here".
+		return "", nil
+	}
+
+	return strings.TrimSpace(pj.Engines.Node), nil
+}
+
+// PackageManagerSpec is the parsed form of package.json's corepack-style
+// "packageManager" field, e.g. "pnpm@8.6.0" -> {Tool: "pnpm", Version: "8.6.0"}.
+type PackageManagerSpec struct {
+	Tool    string
+	Version string
+}
+
+// ReadPackageManagerField reads and parses package.json's "packageManager"
+// field in dir, returning a zero PackageManagerSpec and a nil error if the
+// file, the field, or a well-formed "tool@version" inside it is absent.
+func ReadPackageManagerField(dir string) (PackageManagerSpec, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return PackageManagerSpec{}, nil
+		}
+		return PackageManagerSpec{}, err
+	}
+
+	var pj struct {
+		PackageManager string `json:"packageManager"`
+	}
+	if err := json.Unmarshal(data, &pj); err != nil {
+		// A malformed package.json shouldn't block auto-switching; treat it
+		// the same as "no spec found here".
+		return PackageManagerSpec{}, nil
+	}
+
+	field := strings.TrimSpace(pj.PackageManager)
+	if field == "" {
+		return PackageManagerSpec{}, nil
+	}
+
+	// Corepack allows a build/hash suffix ("yarn@3.6.1+sha224.abcd..."); only
+	// the tool and version before it matter here.
+	field, _, _ = strings.Cut(field, "+")
+
+	tool, ver, ok := strings.Cut(field, "@")
+	if !ok || tool == "" || ver == "" {
+		return PackageManagerSpec{}, nil
+	}
+
+	return PackageManagerSpec{Tool: tool, Version: ver}, nil
+}
+
+// readVersionFile returns path's trimmed contents, or an empty string if it
+// doesn't exist.
+func readVersionFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,109 @@
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Settings holds cross-platform network configuration that is independent of
+// installed versions: mirrors, proxy, and TLS behavior. It is persisted
+// separately from Config so it can be managed with 'nsk config set/get'
+// without touching installed-version state.
+type Settings struct {
+	NodeMirror string `json:"node_mirror"`
+	NpmMirror  string `json:"npm_mirror"`
+	Proxy      string `json:"proxy"`
+	VerifySSL  bool   `json:"verify_ssl"`
+}
+
+// defaultSettings returns the settings used when no settings.json exists yet.
+func defaultSettings() *Settings {
+	return &Settings{
+		NodeMirror: "https://nodejs.org/dist",
+		NpmMirror:  "https://registry.npmjs.org",
+		VerifySSL:  true,
+	}
+}
+
+// GetSettingsPath returns the path to the settings file.
+func GetSettingsPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		panic("Could not get user home directory: " + err.Error())
+	}
+	return filepath.Join(homeDir, ".node-spark", "settings.json")
+}
+
+// LoadSettings loads settings from settingsPath, falling back to defaults if
+// the file doesn't exist yet.
+func LoadSettings(settingsPath string) (*Settings, error) {
+	data, err := os.ReadFile(settingsPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return defaultSettings(), nil
+		}
+		return nil, err
+	}
+
+	settings := defaultSettings()
+	if err := json.Unmarshal(data, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// SaveSettings writes settings to settingsPath, creating its directory if needed.
+func SaveSettings(settingsPath string, settings *Settings) error {
+	if err := os.MkdirAll(filepath.Dir(settingsPath), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(settingsPath, data, 0644)
+}
+
+// Get returns the string value of a settings key, for 'nsk config get <key>'.
+func (s *Settings) Get(key string) (string, error) {
+	switch key {
+	case "node-mirror":
+		return s.NodeMirror, nil
+	case "npm-mirror":
+		return s.NpmMirror, nil
+	case "proxy":
+		return s.Proxy, nil
+	case "verify-ssl":
+		return fmt.Sprintf("%t", s.VerifySSL), nil
+	default:
+		return "", fmt.Errorf("unknown config key: %s", key)
+	}
+}
+
+// Set updates a settings key from a string value, for 'nsk config set <key> <value>'.
+func (s *Settings) Set(key, value string) error {
+	switch key {
+	case "node-mirror":
+		s.NodeMirror = value
+	case "npm-mirror":
+		s.NpmMirror = value
+	case "proxy":
+		s.Proxy = value
+	case "verify-ssl":
+		switch value {
+		case "true", "1", "yes":
+			s.VerifySSL = true
+		case "false", "0", "no":
+			s.VerifySSL = false
+		default:
+			return fmt.Errorf("invalid boolean value for verify-ssl: %s", value)
+		}
+	default:
+		return fmt.Errorf("unknown config key: %s", key)
+	}
+	return nil
+}
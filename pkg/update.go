@@ -0,0 +1,29 @@
+package pkg
+
+// ReleaseAsset describes a single platform's published self-update binary,
+// keyed in ReleaseManifest.Assets by "<GOOS>-<GOARCH>" (e.g. "linux-amd64").
+type ReleaseAsset struct {
+	URL       string `json:"url"`
+	SHA256    string `json:"sha256"`
+	Signature string `json:"signature"` // base64-encoded detached ed25519 signature over the binary
+}
+
+// ReleaseManifest is the parsed form of latest.json, published by
+// node-spark's own release process for each update channel.
+type ReleaseManifest struct {
+	Version string                  `json:"version"`
+	Channel string                  `json:"channel"`
+	Assets  map[string]ReleaseAsset `json:"assets"`
+}
+
+// Updater supplies the release manifest and binary bytes behind SelfUpdate
+// in internal/selfupdate.go, so the update flow can be tested against a fake
+// release source instead of a real HTTP endpoint.
+type Updater interface {
+	// LatestManifest fetches latest.json for the given channel ("stable" or
+	// "beta").
+	LatestManifest(channel string) (ReleaseManifest, error)
+	// DownloadBinary downloads the binary described by asset, returning its
+	// raw bytes.
+	DownloadBinary(asset ReleaseAsset) ([]byte, error)
+}